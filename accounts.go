@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	mcp "github.com/metoro-io/mcp-golang"
+)
+
+const (
+	// defaultAccountName is the account whose token is RAINDROP_TOKEN.
+	defaultAccountName = "default"
+	// accountTokenPrefix starts the variables holding further accounts' tokens,
+	// e.g. RAINDROP_TOKEN_WORK for the account "work".
+	accountTokenPrefix = "RAINDROP_TOKEN_"
+)
+
+// accountsFromEnv collects the named account tokens from environ, a list of
+// KEY=value pairs as returned by os.Environ. Names are lowercased; variables
+// with an empty name or token are ignored.
+func accountsFromEnv(environ []string) map[string]string {
+	accounts := map[string]string{}
+	for _, pair := range environ {
+		key, token, ok := strings.Cut(pair, "=")
+		if !ok || !strings.HasPrefix(key, accountTokenPrefix) {
+			continue
+		}
+		name := strings.ToLower(strings.TrimPrefix(key, accountTokenPrefix))
+		if name == "" || token == "" {
+			continue
+		}
+		accounts[name] = token
+	}
+	return accounts
+}
+
+// activeAccount returns the name and token of the account requests are sent as.
+func (r *RaindropClient) activeAccount() (name string, token string) {
+	r.accountMu.RLock()
+	defer r.accountMu.RUnlock()
+	return r.Account, r.Token
+}
+
+// switchAccount makes subsequent requests use another account. Requests
+// already sent keep the token they started with.
+func (r *RaindropClient) switchAccount(name string, token string) {
+	r.accountMu.Lock()
+	defer r.accountMu.Unlock()
+	r.Account, r.Token = name, token
+}
+
+// accountNames returns the configured account names in alphabetical order.
+func (r *RaindropClient) accountNames() []string {
+	names := make([]string, 0, len(r.Accounts))
+	for name := range r.Accounts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// accountUser fetches the /user profile of the account a token belongs to,
+// without switching the client to that token.
+func (r *RaindropClient) accountUser(ctx context.Context, token string) (map[string]interface{}, error) {
+	probe := &RaindropClient{
+		Token:        token,
+		BaseURL:      r.BaseURL,
+		Timeout:      r.Timeout,
+		RequestHook:  r.RequestHook,
+		ResponseHook: r.ResponseHook,
+	}
+	return probe.currentUser(ctx)
+}
+
+// currentUser fetches the /user profile of the active account.
+func (r *RaindropClient) currentUser(ctx context.Context) (map[string]interface{}, error) {
+	result, err := r.MakeRequest(ctx, "/user", "GET", nil)
+	if err != nil {
+		return nil, err
+	}
+	user, _ := result["user"].(map[string]interface{})
+	return user, nil
+}
+
+type UseAccountArgs struct {
+	Name string `json:"name" jsonschema:"required,description=Name of the account to use, e.g. work for RAINDROP_TOKEN_WORK (default is RAINDROP_TOKEN)"`
+}
+
+func useAccountHandler(client *RaindropClient) func(ctx context.Context, args UseAccountArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args UseAccountArgs) (*mcp.ToolResponse, error) {
+		name := strings.ToLower(strings.TrimSpace(args.Name))
+		token, ok := client.Accounts[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown account %q; configured accounts: %s", args.Name, strings.Join(client.accountNames(), ", "))
+		}
+
+		user, err := client.accountUser(ctx, token)
+		if err != nil {
+			active, _ := client.activeAccount()
+			return nil, fmt.Errorf("account %q could not be verified, still using %q: %v", name, active, err)
+		}
+
+		client.switchAccount(name, token)
+
+		responseText := fmt.Sprintf("Now using account %q", name)
+		if email := stringField(user, "email"); email != "" {
+			responseText += fmt.Sprintf(" (%s)", email)
+		}
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(responseText),
+		), nil
+	}
+}
+
+type ListAccountsArgs struct{}
+
+func listAccountsHandler(client *RaindropClient) func(ctx context.Context, args ListAccountsArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args ListAccountsArgs) (*mcp.ToolResponse, error) {
+		names := client.accountNames()
+		emails := make([]string, len(names))
+		errs := client.forEachConcurrent(ctx, len(names), func(ctx context.Context, i int) error {
+			user, err := client.accountUser(ctx, client.Accounts[names[i]])
+			if err == nil {
+				emails[i] = stringField(user, "email")
+			}
+			return err
+		})
+
+		active, _ := client.activeAccount()
+		var report strings.Builder
+		report.WriteString(fmt.Sprintf("%d accounts configured:", len(names)))
+		for i, name := range names {
+			report.WriteString("\n" + name)
+			switch {
+			case errs[i] != nil:
+				report.WriteString(fmt.Sprintf(" — unverified: %v", errs[i]))
+			case emails[i] != "":
+				report.WriteString(" — " + emails[i])
+			}
+			if name == active {
+				report.WriteString(" (active)")
+			}
+		}
+
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(report.String()),
+		), nil
+	}
+}
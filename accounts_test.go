@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAccountsFromEnv(t *testing.T) {
+	accounts := accountsFromEnv([]string{
+		"RAINDROP_TOKEN=primary",
+		"RAINDROP_TOKEN_WORK=work-token",
+		"RAINDROP_TOKEN_Personal=personal=token",
+		"RAINDROP_TOKEN_EMPTY=",
+		"RAINDROP_TOKEN_=nameless",
+		"HOME=/root",
+	})
+	expected := map[string]string{"work": "work-token", "personal": "personal=token"}
+	if !reflect.DeepEqual(accounts, expected) {
+		t.Errorf("Expected %v, got %v", expected, accounts)
+	}
+}
+
+func TestUseAccount(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/user" {
+			t.Errorf("Unexpected path: %s", r.URL.Path)
+		}
+		switch r.Header.Get("Authorization") {
+		case "Bearer work-token":
+			w.Write([]byte(`{"result": true, "user": {"email": "me@work.example"}}`))
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"result": false, "errorMessage": "Unauthorized"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := &RaindropClient{
+		Token:    "primary",
+		Account:  defaultAccountName,
+		Accounts: map[string]string{defaultAccountName: "primary", "work": "work-token", "stale": "revoked"},
+		BaseURL:  server.URL,
+	}
+	handler := useAccountHandler(client)
+
+	if _, err := handler(context.Background(), UseAccountArgs{Name: "missing"}); err == nil {
+		t.Error("Expected an error for an unknown account")
+	}
+	if _, err := handler(context.Background(), UseAccountArgs{Name: "stale"}); err == nil {
+		t.Error("Expected an error for an account whose token is rejected")
+	}
+	if client.Account != defaultAccountName || client.Token != "primary" {
+		t.Errorf("Expected failed switches to keep the active account, got %q", client.Account)
+	}
+
+	resp, err := handler(context.Background(), UseAccountArgs{Name: "Work"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if client.Account != "work" || client.Token != "work-token" {
+		t.Errorf("Expected to switch to work, got %q", client.Account)
+	}
+	if text := resp.Content[0].TextContent.Text; text != `Now using account "work" (me@work.example)` {
+		t.Errorf("Unexpected response: %s", text)
+	}
+}
+
+func TestListAccounts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Header.Get("Authorization") {
+		case "Bearer primary":
+			w.Write([]byte(`{"result": true, "user": {"email": "me@home.example"}}`))
+		case "Bearer work-token":
+			w.Write([]byte(`{"result": true, "user": {"email": "me@work.example"}}`))
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"result": false, "errorMessage": "Unauthorized"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := &RaindropClient{
+		Token:    "work-token",
+		Account:  "work",
+		Accounts: map[string]string{defaultAccountName: "primary", "work": "work-token", "stale": "revoked"},
+		BaseURL:  server.URL,
+	}
+	resp, err := listAccountsHandler(client)(context.Background(), ListAccountsArgs{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	text := resp.Content[0].TextContent.Text
+	for _, expected := range []string{
+		"3 accounts configured:",
+		"\ndefault — me@home.example\n",
+		"\nstale — unverified: ",
+		"\nwork — me@work.example (active)",
+	} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("Expected %q in:\n%s", expected, text)
+		}
+	}
+	for _, token := range []string{"primary", "work-token", "revoked"} {
+		if strings.Contains(text, token) {
+			t.Errorf("Expected token %q not to be listed:\n%s", token, text)
+		}
+	}
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	mcp "github.com/metoro-io/mcp-golang"
+)
+
+type TypeBreakdownArgs struct {
+	Collection int  `json:"collection,omitempty" jsonschema:"description=Collection ID (0 for all bookmarks)"`
+	JSON       bool `json:"json,omitempty" jsonschema:"description=Return the result as JSON"`
+}
+
+// typeShare is the number and percentage of bookmarks of one content type.
+type typeShare struct {
+	Type    string  `json:"type"`
+	Count   int     `json:"count"`
+	Percent float64 `json:"percent"`
+}
+
+// typeShares lists every known content type, plus any extra types Raindrop
+// reported, with its share of the total.
+func typeShares(counts []tagCount) ([]typeShare, int) {
+	byType := map[string]int{}
+	total := 0
+	for _, c := range counts {
+		byType[c.Tag] += c.Count
+		total += c.Count
+	}
+
+	var shares []typeShare
+	seen := map[string]bool{}
+	add := func(t string) {
+		share := typeShare{Type: t, Count: byType[t]}
+		if total > 0 {
+			share.Percent = float64(share.Count) * 100 / float64(total)
+		}
+		shares = append(shares, share)
+		seen[t] = true
+	}
+	for _, t := range raindropTypes {
+		add(t)
+	}
+	for _, c := range counts {
+		if !seen[c.Tag] {
+			add(c.Tag)
+		}
+	}
+	return shares, total
+}
+
+func typeBreakdownHandler(client *RaindropClient) func(ctx context.Context, args TypeBreakdownArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args TypeBreakdownArgs) (*mcp.ToolResponse, error) {
+		filters, err := client.fetchFilters(ctx, args.Collection)
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+
+		shares, total := typeShares(filters.Types)
+		if args.JSON {
+			return jsonResponse(map[string]interface{}{
+				"collection": args.Collection,
+				"total":      total,
+				"types":      shares,
+			})
+		}
+
+		var table strings.Builder
+		table.WriteString(fmt.Sprintf("Content types in collection %d (%d bookmarks):\n", args.Collection, total))
+		table.WriteString(fmt.Sprintf("%-10s %7s %7s", "Type", "Count", "Share"))
+		for _, share := range shares {
+			table.WriteString(fmt.Sprintf("\n%-10s %7d %6.1f%%", share.Type, share.Count, share.Percent))
+		}
+
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(table.String()),
+		), nil
+	}
+}
+
+const (
+	// defaultTopDomains is how many domains top-domains lists by default.
+	defaultTopDomains = 10
+	// maxTopDomains caps how many domains top-domains lists.
+	maxTopDomains = 100
+	// domainExamples is how many example bookmark IDs top-domains shows per domain.
+	domainExamples = 3
+)
+
+type TopDomainsArgs struct {
+	Collection int  `json:"collection,omitempty" jsonschema:"description=Collection ID (0 for all bookmarks)"`
+	Top        int  `json:"top,omitempty" jsonschema:"description=Number of domains to list (default 10, cap 100)"`
+	JSON       bool `json:"json,omitempty" jsonschema:"description=Return the result as JSON"`
+}
+
+// domainCount is how many bookmarks were saved from a domain.
+type domainCount struct {
+	Domain   string `json:"domain"`
+	Count    int    `json:"count"`
+	Examples []int  `json:"examples"`
+}
+
+// normalizeDomain lowercases a host and drops its port and any www. prefix.
+func normalizeDomain(host string) string {
+	host = strings.ToLower(strings.TrimSpace(host))
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return strings.TrimPrefix(host, "www.")
+}
+
+// countDomains tallies the domains of items, most saved first (ties by name),
+// keeping the IDs of the first few bookmarks from each as examples.
+func countDomains(items []map[string]interface{}) []domainCount {
+	byDomain := map[string]*domainCount{}
+	for _, item := range items {
+		domain := normalizeDomain(bookmarkHost(item))
+		if domain == "" {
+			continue
+		}
+		count, ok := byDomain[domain]
+		if !ok {
+			count = &domainCount{Domain: domain, Examples: []int{}}
+			byDomain[domain] = count
+		}
+		count.Count++
+		if len(count.Examples) < domainExamples {
+			count.Examples = append(count.Examples, intField(item, "_id"))
+		}
+	}
+
+	counts := make([]domainCount, 0, len(byDomain))
+	for _, count := range byDomain {
+		counts = append(counts, *count)
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Count != counts[j].Count {
+			return counts[i].Count > counts[j].Count
+		}
+		return counts[i].Domain < counts[j].Domain
+	})
+	return counts
+}
+
+func topDomainsHandler(client *RaindropClient) func(ctx context.Context, args TopDomainsArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args TopDomainsArgs) (*mcp.ToolResponse, error) {
+		top := args.Top
+		if top <= 0 {
+			top = defaultTopDomains
+		}
+		if top > maxTopDomains {
+			top = maxTopDomains
+		}
+
+		ctx, cancel := client.bulkContext(ctx)
+		defer cancel()
+
+		items, err := client.listRaindrops(ctx, args.Collection, url.Values{}, maxExportItems)
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+
+		counts := countDomains(items)
+		totalDomains := len(counts)
+		if len(counts) > top {
+			counts = counts[:top]
+		}
+
+		if args.JSON {
+			return jsonResponse(map[string]interface{}{
+				"collection": args.Collection,
+				"bookmarks":  len(items),
+				"domains":    totalDomains,
+				"top":        counts,
+			})
+		}
+
+		if len(counts) == 0 {
+			return mcp.NewToolResponse(
+				mcp.NewTextContent("No bookmarks found in this collection."),
+			), nil
+		}
+
+		var table strings.Builder
+		table.WriteString(fmt.Sprintf("Top %d of %d domains in collection %d (%d bookmarks):", len(counts), totalDomains, args.Collection, len(items)))
+		for _, count := range counts {
+			examples := make([]string, len(count.Examples))
+			for i, id := range count.Examples {
+				examples[i] = strconv.Itoa(id)
+			}
+			table.WriteString(fmt.Sprintf("\n%s: %d (e.g. %s)", count.Domain, count.Count, strings.Join(examples, ", ")))
+		}
+
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(table.String()),
+		), nil
+	}
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTypeShares(t *testing.T) {
+	shares, total := typeShares([]tagCount{{Tag: "article", Count: 3}, {Tag: "link", Count: 1}, {Tag: "book", Count: 4}})
+	if total != 8 {
+		t.Errorf("Expected total 8, got %d", total)
+	}
+	if len(shares) != len(raindropTypes)+1 {
+		t.Fatalf("Expected every known type plus book, got %v", shares)
+	}
+
+	byType := map[string]typeShare{}
+	for _, share := range shares {
+		byType[share.Type] = share
+	}
+	if byType["article"].Count != 3 || byType["article"].Percent != 37.5 {
+		t.Errorf("Unexpected article share: %+v", byType["article"])
+	}
+	if byType["video"].Count != 0 || byType["video"].Percent != 0 {
+		t.Errorf("Unexpected video share: %+v", byType["video"])
+	}
+	if shares[len(shares)-1].Type != "book" {
+		t.Errorf("Expected unknown type last, got %+v", shares[len(shares)-1])
+	}
+}
+
+func TestCountDomains(t *testing.T) {
+	items := []map[string]interface{}{
+		{"_id": float64(1), "domain": "www.GitHub.com"},
+		{"_id": float64(2), "link": "https://go.dev/doc"},
+		{"_id": float64(3), "domain": "github.com"},
+		{"_id": float64(4), "domain": "github.com:443"},
+		{"_id": float64(5), "domain": "github.com"},
+		{"_id": float64(6), "domain": "blog.example"},
+		{"_id": float64(7)},
+	}
+
+	counts := countDomains(items)
+	expected := []domainCount{
+		{Domain: "github.com", Count: 4, Examples: []int{1, 3, 4}},
+		{Domain: "blog.example", Count: 1, Examples: []int{6}},
+		{Domain: "go.dev", Count: 1, Examples: []int{2}},
+	}
+	if !reflect.DeepEqual(counts, expected) {
+		t.Errorf("Expected %v, got %v", expected, counts)
+	}
+}
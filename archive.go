@@ -0,0 +1,184 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	mcp "github.com/metoro-io/mcp-golang"
+)
+
+// waybackAvailabilityAPI is the Wayback Machine's snapshot lookup endpoint.
+// It is a variable so tests can point it at a mock server.
+var waybackAvailabilityAPI = "https://archive.org/wayback/available"
+
+// archiveTimeout bounds a snapshot lookup; the Wayback Machine can be slow.
+const archiveTimeout = 15 * time.Second
+
+// snapshot is an archived copy of a page.
+type snapshot struct {
+	URL       string
+	Timestamp time.Time
+}
+
+// findSnapshot asks the Wayback Machine for the archived copy of link closest
+// to now. ok is false when no snapshot is available.
+func findSnapshot(ctx context.Context, link string) (snap snapshot, ok bool, err error) {
+	ctx, cancel := context.WithTimeout(ctx, archiveTimeout)
+	defer cancel()
+
+	params := url.Values{}
+	params.Set("url", link)
+	req, err := http.NewRequestWithContext(ctx, "GET", waybackAvailabilityAPI+"?"+params.Encode(), nil)
+	if err != nil {
+		return snapshot{}, false, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return snapshot{}, false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return snapshot{}, false, fmt.Errorf("Wayback Machine error: %s", resp.Status)
+	}
+
+	var payload struct {
+		ArchivedSnapshots struct {
+			Closest struct {
+				Available bool   `json:"available"`
+				URL       string `json:"url"`
+				Timestamp string `json:"timestamp"`
+				Status    string `json:"status"`
+			} `json:"closest"`
+		} `json:"archived_snapshots"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return snapshot{}, false, fmt.Errorf("unable to parse Wayback Machine response: %v", err)
+	}
+
+	closest := payload.ArchivedSnapshots.Closest
+	if !closest.Available || closest.URL == "" || (closest.Status != "" && closest.Status != "200") {
+		return snapshot{}, false, nil
+	}
+
+	snap = snapshot{URL: closest.URL}
+	if t, err := time.Parse("20060102150405", closest.Timestamp); err == nil {
+		snap.Timestamp = t
+	}
+	// The API reports plain http links; the archive serves the same path over https.
+	if parsed, err := url.Parse(snap.URL); err == nil && parsed.Scheme == "http" && parsed.Host == "web.archive.org" {
+		parsed.Scheme = "https"
+		snap.URL = parsed.String()
+	}
+	return snap, true, nil
+}
+
+type SuggestFixBrokenArgs struct {
+	ID int `json:"id" jsonschema:"required,description=ID of the broken bookmark"`
+}
+
+func suggestFixBrokenHandler(client *RaindropClient) func(ctx context.Context, args SuggestFixBrokenArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args SuggestFixBrokenArgs) (*mcp.ToolResponse, error) {
+		if args.ID == 0 {
+			return nil, fmt.Errorf("ID is required")
+		}
+
+		bookmark, err := client.getRaindrop(ctx, args.ID)
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+		link := stringField(bookmark, "link")
+
+		snap, ok, err := findSnapshot(ctx, link)
+		if err != nil {
+			return nil, fmt.Errorf("unable to check the Wayback Machine for %s: %v", link, err)
+		}
+		if !ok {
+			return mcp.NewToolResponse(
+				mcp.NewTextContent(fmt.Sprintf("No archived snapshot is available for %s.", link)),
+			), nil
+		}
+
+		archived := "unknown date"
+		if !snap.Timestamp.IsZero() {
+			archived = snap.Timestamp.Format("2006-01-02")
+		}
+
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(fmt.Sprintf("Suggested replacement for bookmark %d:\nOriginal: %s\nArchived copy: %s (captured %s)\nUpdate the bookmark's link to the archived copy to fix it.",
+				args.ID, link, snap.URL, archived)),
+		), nil
+	}
+}
+
+type RescueBrokenLinksArgs struct {
+	Collection int  `json:"collection" jsonschema:"description=Collection ID to check (0 for all bookmarks)"`
+	Apply      bool `json:"apply,omitempty" jsonschema:"description=Replace broken links with their archived copies (default only reports)"`
+}
+
+func rescueBrokenLinksHandler(client *RaindropClient) func(ctx context.Context, args RescueBrokenLinksArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args RescueBrokenLinksArgs) (*mcp.ToolResponse, error) {
+		ctx, cancel := client.bulkContext(ctx)
+		defer cancel()
+
+		params := url.Values{}
+		params.Set("search", "broken:true")
+		items, err := client.listRaindrops(ctx, args.Collection, params, maxExportItems)
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+		if len(items) == 0 {
+			return mcp.NewToolResponse(
+				mcp.NewTextContent("No broken links found."),
+			), nil
+		}
+
+		snapshots := make([]snapshot, len(items))
+		found := make([]bool, len(items))
+		errs := client.forEachConcurrent(ctx, len(items), func(ctx context.Context, i int) error {
+			snap, ok, err := findSnapshot(ctx, stringField(items[i], "link"))
+			if err != nil || !ok {
+				return err
+			}
+			snapshots[i], found[i] = snap, true
+			if !args.Apply {
+				return nil
+			}
+			_, err = client.updateRaindrop(ctx, intField(items[i], "_id"), map[string]interface{}{"link": snap.URL})
+			return err
+		})
+
+		var report strings.Builder
+		rescued, unrecoverable, failed := 0, 0, 0
+		for i, item := range items {
+			id, link := intField(item, "_id"), stringField(item, "link")
+			switch {
+			case errs[i] != nil:
+				failed++
+				report.WriteString(fmt.Sprintf("\nBookmark %d: failed (%v)", id, errs[i]))
+			case found[i]:
+				rescued++
+				report.WriteString(fmt.Sprintf("\nBookmark %d: %s -> %s", id, link, snapshots[i].URL))
+			default:
+				unrecoverable++
+				report.WriteString(fmt.Sprintf("\nBookmark %d: no snapshot for %s", id, link))
+			}
+		}
+
+		verb := "Rescued"
+		if !args.Apply {
+			verb = "Can rescue"
+		}
+
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(fmt.Sprintf("%s %d of %d broken links (%d unrecoverable, %d failed):%s",
+				verb, rescued, len(items), unrecoverable, failed, report.String())),
+		), nil
+	}
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFindSnapshot(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("url") {
+		case "https://gone.example/page":
+			w.Write([]byte(`{"archived_snapshots": {"closest": {"available": true, "status": "200", "timestamp": "20130919044612", "url": "http://web.archive.org/web/20130919044612/https://gone.example/page"}}}`))
+		default:
+			w.Write([]byte(`{"archived_snapshots": {}}`))
+		}
+	}))
+	defer server.Close()
+
+	original := waybackAvailabilityAPI
+	waybackAvailabilityAPI = server.URL
+	defer func() { waybackAvailabilityAPI = original }()
+
+	snap, ok, err := findSnapshot(context.Background(), "https://gone.example/page")
+	if err != nil || !ok {
+		t.Fatalf("Expected snapshot, got %v, %v", ok, err)
+	}
+	if snap.URL != "https://web.archive.org/web/20130919044612/https://gone.example/page" {
+		t.Errorf("Unexpected snapshot URL: %s", snap.URL)
+	}
+	if snap.Timestamp.Format("2006-01-02 15:04:05") != "2013-09-19 04:46:12" {
+		t.Errorf("Unexpected snapshot time: %v", snap.Timestamp)
+	}
+
+	if _, ok, err := findSnapshot(context.Background(), "https://never.example"); ok || err != nil {
+		t.Errorf("Expected no snapshot, got %v, %v", ok, err)
+	}
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	mcp "github.com/metoro-io/mcp-golang"
+)
+
+// within reports whether path is dir or lies below it. Both must be clean.
+func within(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// resolveBackupPath turns the path a caller asked for into an absolute file
+// path inside dir. Relative paths are taken relative to dir, and an empty path
+// picks a timestamped file name. The file's directory must already exist and,
+// with symlinks resolved, still lie inside dir.
+func resolveBackupPath(dir, path string, collection int, now time.Time) (string, error) {
+	if dir == "" {
+		return "", errors.New("backups are disabled; set RAINDROP_BACKUP_DIR to the directory backup-to-file may write to")
+	}
+	base, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	base, err = filepath.EvalSymlinks(base)
+	if err != nil {
+		return "", fmt.Errorf("RAINDROP_BACKUP_DIR is not usable: %v", err)
+	}
+
+	if path == "" {
+		path = fmt.Sprintf("raindrop-backup-%d-%s.json", collection, now.UTC().Format("20060102-150405"))
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(base, path)
+	}
+	path = filepath.Clean(path)
+
+	parent, err := filepath.EvalSymlinks(filepath.Dir(path))
+	if err != nil {
+		return "", fmt.Errorf("invalid backup path %q: %v", path, err)
+	}
+	target := filepath.Join(parent, filepath.Base(path))
+	if !within(base, target) || target == base {
+		return "", fmt.Errorf("invalid backup path %q: must be a file inside %s", path, base)
+	}
+	if info, err := os.Stat(target); err == nil && info.IsDir() {
+		return "", fmt.Errorf("invalid backup path %q: is a directory", path)
+	}
+	return target, nil
+}
+
+// writeFileAtomic writes data to path through a temporary file in the same
+// directory, so an interrupted write never leaves a truncated backup behind.
+func writeFileAtomic(path string, data []byte) error {
+	file, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), path)
+}
+
+type BackupToFileArgs struct {
+	Collection int    `json:"collection" jsonschema:"description=Collection ID to back up (0 for all bookmarks)"`
+	Path       string `json:"path,omitempty" jsonschema:"description=File to write, relative to RAINDROP_BACKUP_DIR (defaults to a timestamped name); an existing file is replaced"`
+}
+
+func backupToFileHandler(client *RaindropClient) func(ctx context.Context, args BackupToFileArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args BackupToFileArgs) (*mcp.ToolResponse, error) {
+		path, err := resolveBackupPath(client.BackupDir, args.Path, args.Collection, time.Now())
+		if err != nil {
+			return nil, err
+		}
+
+		ctx, cancel := client.bulkContext(ctx)
+		defer cancel()
+
+		export, err := client.exportJSON(ctx, args.Collection)
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+		data, err := json.MarshalIndent(export, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		if err := writeFileAtomic(path, data); err != nil {
+			return nil, fmt.Errorf("unable to write backup: %v", err)
+		}
+
+		responseText := fmt.Sprintf("Backed up %d bookmarks to %s", export.Count, path)
+		if export.Count == maxExportItems {
+			responseText += fmt.Sprintf("\nThe backup stopped at the export limit of %d bookmarks.", maxExportItems)
+		}
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(responseText),
+		), nil
+	}
+}
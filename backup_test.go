@@ -0,0 +1,66 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestResolveBackupPath(t *testing.T) {
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	outside := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "weekly"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(outside, filepath.Join(dir, "escape")); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2024, 3, 4, 5, 6, 7, 0, time.UTC)
+
+	valid := map[string]string{
+		"":                 filepath.Join(dir, "raindrop-backup-12-20240304-050607.json"),
+		"reading.json":     filepath.Join(dir, "reading.json"),
+		"weekly/../a.json": filepath.Join(dir, "a.json"),
+		"weekly/b.json":    filepath.Join(dir, "weekly", "b.json"),
+		dir + "/abs.json":  filepath.Join(dir, "abs.json"),
+	}
+	for input, expected := range valid {
+		got, err := resolveBackupPath(dir, input, 12, now)
+		if err != nil || got != expected {
+			t.Errorf("resolveBackupPath(%q) = %q, %v, expected %q", input, got, err, expected)
+		}
+	}
+
+	for _, input := range []string{"../x.json", "/etc/passwd", "escape/x.json", "missing/x.json", "weekly", "."} {
+		if got, err := resolveBackupPath(dir, input, 12, now); err == nil {
+			t.Errorf("Expected %q to be rejected, got %q", input, got)
+		}
+	}
+
+	if _, err := resolveBackupPath("", "x.json", 0, now); err == nil {
+		t.Error("Expected backups to be disabled without RAINDROP_BACKUP_DIR")
+	}
+}
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "backup.json")
+	for _, content := range []string{"first", "second"} {
+		if err := writeFileAtomic(path, []byte(content)); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil || string(data) != "second" {
+		t.Errorf("Expected the file to be replaced, got %q, %v", data, err)
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("Expected no temporary files left behind, got %d entries", len(entries))
+	}
+}
@@ -0,0 +1,795 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	mcp "github.com/metoro-io/mcp-golang"
+)
+
+// wantsParse reports whether a new bookmark should be created with Raindrop's
+// pleaseParse directive: as requested, or by default when it has no title so
+// Raindrop fills one in instead of saving it untitled.
+func wantsParse(title string, parse *bool) bool {
+	if parse != nil {
+		return *parse
+	}
+	return strings.TrimSpace(title) == ""
+}
+
+type SetCreatedDateArgs struct {
+	ID   int    `json:"id" jsonschema:"required,description=Bookmark ID"`
+	Date string `json:"date" jsonschema:"required,description=Creation date as YYYY-MM-DD or RFC 3339"`
+}
+
+func setCreatedDateHandler(client *RaindropClient) func(ctx context.Context, args SetCreatedDateArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args SetCreatedDateArgs) (*mcp.ToolResponse, error) {
+		if args.ID == 0 {
+			return nil, fmt.Errorf("ID is required")
+		}
+
+		created, err := parseDate(args.Date)
+		if err != nil {
+			return nil, err
+		}
+
+		body := map[string]interface{}{
+			"created": created.Format(time.RFC3339),
+		}
+
+		result, err := client.MakeRequest(ctx, fmt.Sprintf("/raindrop/%d", args.ID), "PUT", body)
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+
+		applied := created.Format(time.RFC3339)
+		if item, ok := result["item"].(map[string]interface{}); ok {
+			if value := stringField(item, "created"); value != "" {
+				applied = value
+			}
+		}
+
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(fmt.Sprintf("Bookmark %d created date set to %s. Date-sorted views will now place it accordingly.", args.ID, applied)),
+		), nil
+	}
+}
+
+type RefreshMetadataArgs struct {
+	ID    int  `json:"id" jsonschema:"required,description=Bookmark ID"`
+	Force bool `json:"force,omitempty" jsonschema:"description=Overwrite fields that already have a value"`
+}
+
+// parsedCover returns the cover image from a parse response, falling back to the first media item.
+func parsedCover(parsed map[string]interface{}) string {
+	if cover := stringField(parsed, "cover"); cover != "" {
+		return cover
+	}
+	if media, ok := parsed["media"].([]interface{}); ok && len(media) > 0 {
+		if first, ok := media[0].(map[string]interface{}); ok {
+			return stringField(first, "link")
+		}
+	}
+	return ""
+}
+
+// mergeMetadata returns the title/excerpt/cover fields from parsed that should be
+// written to current. Empty fields are always filled; others only when force is set.
+func mergeMetadata(current, parsed map[string]interface{}, force bool) map[string]interface{} {
+	candidates := map[string]string{
+		"title":   stringField(parsed, "title"),
+		"excerpt": stringField(parsed, "excerpt"),
+		"cover":   parsedCover(parsed),
+	}
+
+	changes := map[string]interface{}{}
+	for field, value := range candidates {
+		existing := stringField(current, field)
+		if value == "" || value == existing {
+			continue
+		}
+		if existing == "" || force {
+			changes[field] = value
+		}
+	}
+	return changes
+}
+
+func refreshMetadataHandler(client *RaindropClient) func(ctx context.Context, args RefreshMetadataArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args RefreshMetadataArgs) (*mcp.ToolResponse, error) {
+		if args.ID == 0 {
+			return nil, fmt.Errorf("ID is required")
+		}
+
+		bookmark, err := client.getRaindrop(ctx, args.ID)
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+
+		parsed, err := client.parseURL(ctx, stringField(bookmark, "link"))
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+
+		changes := mergeMetadata(bookmark, parsed, args.Force)
+		if len(changes) == 0 {
+			return mcp.NewToolResponse(
+				mcp.NewTextContent(fmt.Sprintf("Bookmark %d metadata is already up to date.", args.ID)),
+			), nil
+		}
+
+		if _, err := client.updateRaindrop(ctx, args.ID, changes); err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+
+		fields := make([]string, 0, len(changes))
+		for field := range changes {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+
+		var report strings.Builder
+		for _, field := range fields {
+			report.WriteString(fmt.Sprintf("\n%s: %q -> %q", field, stringField(bookmark, field), changes[field]))
+		}
+
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(fmt.Sprintf("Bookmark %d metadata refreshed:%s", args.ID, report.String())),
+		), nil
+	}
+}
+
+type RenameBookmarkArgs struct {
+	ID    int    `json:"id" jsonschema:"required,description=Bookmark ID"`
+	Title string `json:"title" jsonschema:"required,description=New title"`
+}
+
+func renameBookmarkHandler(client *RaindropClient) func(ctx context.Context, args RenameBookmarkArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args RenameBookmarkArgs) (*mcp.ToolResponse, error) {
+		if args.ID == 0 {
+			return nil, fmt.Errorf("ID is required")
+		}
+		title := strings.TrimSpace(args.Title)
+		if title == "" {
+			return nil, fmt.Errorf("title is required")
+		}
+
+		bookmark, err := client.getRaindrop(ctx, args.ID)
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+
+		if _, err := client.updateRaindrop(ctx, args.ID, map[string]interface{}{"title": title}); err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(fmt.Sprintf("Bookmark %d renamed from %q to %q", args.ID, stringField(bookmark, "title"), title)),
+		), nil
+	}
+}
+
+type SwapCollectionArgs struct {
+	ID          int `json:"id" jsonschema:"required,description=Bookmark ID"`
+	CollectionA int `json:"collectionA" jsonschema:"required,description=First collection ID"`
+	CollectionB int `json:"collectionB" jsonschema:"required,description=Second collection ID"`
+}
+
+// swapTarget returns the collection a bookmark in current should move to.
+// Bookmarks in neither collection move to a.
+func swapTarget(current, a, b int) int {
+	if current == a {
+		return b
+	}
+	return a
+}
+
+func swapCollectionHandler(client *RaindropClient) func(ctx context.Context, args SwapCollectionArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args SwapCollectionArgs) (*mcp.ToolResponse, error) {
+		if args.ID == 0 {
+			return nil, fmt.Errorf("ID is required")
+		}
+		if args.CollectionA == args.CollectionB {
+			return nil, fmt.Errorf("collectionA and collectionB must differ")
+		}
+
+		bookmark, err := client.getRaindrop(ctx, args.ID)
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+
+		current := collectionIDOf(bookmark)
+		target := swapTarget(current, args.CollectionA, args.CollectionB)
+
+		body := map[string]interface{}{
+			"collection": map[string]interface{}{"$id": target},
+		}
+		if _, err := client.updateRaindrop(ctx, args.ID, body); err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(fmt.Sprintf("Bookmark %d moved from collection %d to collection %d", args.ID, current, target)),
+		), nil
+	}
+}
+
+type MergeBookmarksArgs struct {
+	KeepID  int `json:"keepId" jsonschema:"required,description=ID of the bookmark to keep"`
+	MergeID int `json:"mergeId" jsonschema:"required,description=ID of the bookmark to merge into the kept one and then delete"`
+}
+
+// mergeBookmarkFields returns the fields to write to keep so it gains merge's
+// metadata: the union of tags, merge's excerpt if keep has none, and merge's
+// note appended to keep's.
+func mergeBookmarkFields(keep, merge map[string]interface{}) map[string]interface{} {
+	changes := map[string]interface{}{}
+
+	keepTags := extractTags(keep)
+	if tags := mergeTags(keepTags, extractTags(merge)); len(tags) != len(keepTags) {
+		changes["tags"] = tags
+	}
+
+	if stringField(keep, "excerpt") == "" && stringField(merge, "excerpt") != "" {
+		changes["excerpt"] = stringField(merge, "excerpt")
+	}
+
+	keepNote, mergeNote := stringField(keep, "note"), stringField(merge, "note")
+	switch {
+	case mergeNote == "" || mergeNote == keepNote:
+	case keepNote == "":
+		changes["note"] = mergeNote
+	default:
+		changes["note"] = keepNote + "\n\n" + mergeNote
+	}
+
+	return changes
+}
+
+func mergeBookmarksHandler(client *RaindropClient) func(ctx context.Context, args MergeBookmarksArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args MergeBookmarksArgs) (*mcp.ToolResponse, error) {
+		if args.KeepID == 0 || args.MergeID == 0 {
+			return nil, fmt.Errorf("keepId and mergeId are required")
+		}
+		if args.KeepID == args.MergeID {
+			return nil, fmt.Errorf("keepId and mergeId must differ")
+		}
+
+		keep, err := client.getRaindrop(ctx, args.KeepID)
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+		merge, err := client.getRaindrop(ctx, args.MergeID)
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+
+		changes := mergeBookmarkFields(keep, merge)
+		if len(changes) > 0 {
+			if _, err := client.updateRaindrop(ctx, args.KeepID, changes); err != nil {
+				return nil, fmt.Errorf("internal error: %v", err)
+			}
+		}
+
+		if _, err := client.MakeRequest(ctx, fmt.Sprintf("/raindrop/%d", args.MergeID), "DELETE", nil); err != nil {
+			return nil, fmt.Errorf("internal error: merged into %d but failed to delete %d: %v", args.KeepID, args.MergeID, err)
+		}
+
+		merged := "no new metadata"
+		if len(changes) > 0 {
+			fields := make([]string, 0, len(changes))
+			for field := range changes {
+				fields = append(fields, field)
+			}
+			sort.Strings(fields)
+			merged = strings.Join(fields, ", ")
+		}
+
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(fmt.Sprintf("Merged bookmark %d into %d (%s) and moved %d to trash", args.MergeID, args.KeepID, merged, args.MergeID)),
+		), nil
+	}
+}
+
+type GetNoteArgs struct {
+	ID int `json:"id" jsonschema:"required,description=Bookmark ID"`
+}
+
+func getNoteHandler(client *RaindropClient) func(ctx context.Context, args GetNoteArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args GetNoteArgs) (*mcp.ToolResponse, error) {
+		if args.ID == 0 {
+			return nil, fmt.Errorf("ID is required")
+		}
+
+		bookmark, err := client.getRaindrop(ctx, args.ID)
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+
+		note := stringField(bookmark, "note")
+		if note == "" {
+			return mcp.NewToolResponse(
+				mcp.NewTextContent(fmt.Sprintf("Bookmark %d has no note.", args.ID)),
+			), nil
+		}
+
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(note),
+		), nil
+	}
+}
+
+type SetNoteArgs struct {
+	ID   int    `json:"id" jsonschema:"required,description=Bookmark ID"`
+	Note string `json:"note" jsonschema:"description=Private note text; empty clears the note"`
+}
+
+func setNoteHandler(client *RaindropClient) func(ctx context.Context, args SetNoteArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args SetNoteArgs) (*mcp.ToolResponse, error) {
+		if args.ID == 0 {
+			return nil, fmt.Errorf("ID is required")
+		}
+
+		item, err := client.updateRaindrop(ctx, args.ID, map[string]interface{}{"note": args.Note})
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+
+		note := args.Note
+		if item != nil {
+			note = stringField(item, "note")
+		}
+		if note == "" {
+			return mcp.NewToolResponse(
+				mcp.NewTextContent(fmt.Sprintf("Note cleared on bookmark %d", args.ID)),
+			), nil
+		}
+
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(fmt.Sprintf("Note saved on bookmark %d:\n%s", args.ID, note)),
+		), nil
+	}
+}
+
+type RegenerateCoverArgs struct {
+	ID    int  `json:"id" jsonschema:"required,description=Bookmark ID"`
+	Clear bool `json:"clear,omitempty" jsonschema:"description=Remove the cover instead of regenerating it"`
+}
+
+func regenerateCoverHandler(client *RaindropClient) func(ctx context.Context, args RegenerateCoverArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args RegenerateCoverArgs) (*mcp.ToolResponse, error) {
+		if args.ID == 0 {
+			return nil, fmt.Errorf("ID is required")
+		}
+
+		if args.Clear {
+			if _, err := client.updateRaindrop(ctx, args.ID, map[string]interface{}{"cover": ""}); err != nil {
+				return nil, fmt.Errorf("internal error: %v", err)
+			}
+			return mcp.NewToolResponse(
+				mcp.NewTextContent(fmt.Sprintf("Bookmark %d cover cleared.", args.ID)),
+			), nil
+		}
+
+		bookmark, err := client.getRaindrop(ctx, args.ID)
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+
+		// The parser suggests covers for the page: its preview image, then any other media.
+		parsed, err := client.parseURL(ctx, stringField(bookmark, "link"))
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+		cover := parsedCover(parsed)
+		if cover == "" {
+			return mcp.NewToolResponse(
+				mcp.NewTextContent(fmt.Sprintf("Raindrop found no cover for bookmark %d; use clear to remove the current one.", args.ID)),
+			), nil
+		}
+
+		if _, err := client.updateRaindrop(ctx, args.ID, map[string]interface{}{"cover": cover}); err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(fmt.Sprintf("Bookmark %d cover set to %s", args.ID, cover)),
+		), nil
+	}
+}
+
+type GetBookmarkTagsArgs struct {
+	ID int `json:"id" jsonschema:"required,description=Bookmark ID"`
+}
+
+func getBookmarkTagsHandler(client *RaindropClient) func(ctx context.Context, args GetBookmarkTagsArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args GetBookmarkTagsArgs) (*mcp.ToolResponse, error) {
+		if args.ID == 0 {
+			return nil, fmt.Errorf("ID is required")
+		}
+
+		bookmark, err := client.getRaindrop(ctx, args.ID)
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+
+		return jsonResponse(extractTags(bookmark))
+	}
+}
+
+// linkedTagPrefix marks copies made by file-in-multiple with their original's ID.
+const linkedTagPrefix = "linked:"
+
+// bookmarkCopy builds the create payload for a copy of bookmark in collection,
+// tagged with linked:<original ID>.
+func bookmarkCopy(bookmark map[string]interface{}, collection int) map[string]interface{} {
+	item := map[string]interface{}{
+		"link":       stringField(bookmark, "link"),
+		"collection": map[string]interface{}{"$id": collection},
+		"tags":       mergeTags(extractTags(bookmark), []string{fmt.Sprintf("%s%d", linkedTagPrefix, intField(bookmark, "_id"))}),
+	}
+	for _, field := range []string{"title", "excerpt", "note", "type", "cover"} {
+		if value := stringField(bookmark, field); value != "" {
+			item[field] = value
+		}
+	}
+	if important, _ := bookmark["important"].(bool); important {
+		item["important"] = true
+	}
+	return item
+}
+
+type FileInMultipleArgs struct {
+	ID          int   `json:"id" jsonschema:"required,description=ID of the bookmark to copy"`
+	Collections []int `json:"collections" jsonschema:"required,description=IDs of the additional collections to file the bookmark in"`
+}
+
+func fileInMultipleHandler(client *RaindropClient) func(ctx context.Context, args FileInMultipleArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args FileInMultipleArgs) (*mcp.ToolResponse, error) {
+		if args.ID == 0 {
+			return nil, fmt.Errorf("ID is required")
+		}
+
+		bookmark, err := client.getRaindrop(ctx, args.ID)
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+		source := collectionIDOf(bookmark)
+
+		var targets []int
+		seen := map[int]bool{source: true}
+		for _, id := range args.Collections {
+			if !seen[id] {
+				seen[id] = true
+				targets = append(targets, id)
+			}
+		}
+		if len(targets) == 0 {
+			return nil, fmt.Errorf("at least one collection other than the bookmark's own (%d) is required", source)
+		}
+
+		items := make([]map[string]interface{}, len(targets))
+		for i, id := range targets {
+			items[i] = bookmarkCopy(bookmark, id)
+		}
+
+		ctx, cancel := client.bulkContext(ctx)
+		defer cancel()
+
+		created, result := client.createRaindrops(ctx, items, false)
+		if len(created) == 0 {
+			return nil, fmt.Errorf("internal error: %s", result.Failed[0].Err)
+		}
+
+		var report strings.Builder
+		filed := map[int]bool{}
+		for _, item := range created {
+			filed[collectionIDOf(item)] = true
+			report.WriteString(fmt.Sprintf("\nCollection %d: bookmark %d", collectionIDOf(item), intField(item, "_id")))
+		}
+		for _, id := range targets {
+			if !filed[id] {
+				report.WriteString(fmt.Sprintf("\nCollection %d: not filed", id))
+			}
+		}
+		report.WriteString(result.FailedText())
+
+		// Tag the original too, so it turns up alongside its copies. The
+		// copies exist by now, so a failure here is reported, not returned.
+		tag := fmt.Sprintf("%s%d", linkedTagPrefix, args.ID)
+		if _, err := client.updateRaindrop(ctx, args.ID, map[string]interface{}{"tags": mergeTags(extractTags(bookmark), []string{tag})}); err != nil {
+			report.WriteString(fmt.Sprintf("\nFailed to tag the original with #%s: %v", tag, err))
+		}
+
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(fmt.Sprintf("Filed bookmark %d in %d of %d more collections (find them all with #%s):%s", args.ID, len(created), len(targets), tag, report.String())),
+		), nil
+	}
+}
+
+type BackfillExcerptsArgs struct {
+	Collection int  `json:"collection" jsonschema:"description=Collection ID to fill (0 for all bookmarks)"`
+	DryRun     bool `json:"dryRun,omitempty" jsonschema:"description=Report the excerpts that would be set without changing anything"`
+}
+
+func backfillExcerptsHandler(client *RaindropClient) func(ctx context.Context, args BackfillExcerptsArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args BackfillExcerptsArgs) (*mcp.ToolResponse, error) {
+		ctx, cancel := client.bulkContext(ctx)
+		defer cancel()
+
+		items, err := client.listRaindrops(ctx, args.Collection, url.Values{}, maxExportItems)
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+
+		var targets []map[string]interface{}
+		for _, item := range items {
+			if strings.TrimSpace(stringField(item, "excerpt")) == "" {
+				targets = append(targets, item)
+			}
+		}
+		if len(targets) == 0 {
+			return mcp.NewToolResponse(
+				mcp.NewTextContent(fmt.Sprintf("All %d bookmarks already have excerpts.", len(items))),
+			), nil
+		}
+
+		excerpts := make([]string, len(targets))
+		errs := client.forEachConcurrent(ctx, len(targets), func(ctx context.Context, i int) error {
+			parsed, err := client.parseURL(ctx, stringField(targets[i], "link"))
+			if err != nil {
+				return err
+			}
+			excerpts[i] = strings.Join(strings.Fields(stringField(parsed, "excerpt")), " ")
+			if excerpts[i] == "" || args.DryRun {
+				return nil
+			}
+			_, err = client.updateRaindrop(ctx, intField(targets[i], "_id"), map[string]interface{}{"excerpt": excerpts[i]})
+			return err
+		})
+
+		var report strings.Builder
+		filled, missing := 0, 0
+		for i, item := range targets {
+			id := intField(item, "_id")
+			switch {
+			case errs[i] != nil:
+				report.WriteString(fmt.Sprintf("\nBookmark %d: failed (%v)", id, errs[i]))
+			case excerpts[i] == "":
+				missing++
+				report.WriteString(fmt.Sprintf("\nBookmark %d: page has no description", id))
+			default:
+				filled++
+				report.WriteString(fmt.Sprintf("\nBookmark %d: %q", id, excerpts[i]))
+			}
+		}
+
+		verb := "Filled"
+		if args.DryRun {
+			verb = "Would fill"
+		}
+
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(fmt.Sprintf("%s %d of %d bookmarks without an excerpt (%d pages have no description):%s",
+				verb, filled, len(targets), missing, report.String())),
+		), nil
+	}
+}
+
+// maxPatternLength caps bulk-rename-titles patterns. Go's regexp runs in linear
+// time, so this only bounds the cost of compiling and matching the pattern.
+const maxPatternLength = 512
+
+// titleReplacer returns a function applying the find/replace to a title. With
+// regex, find is a Go regular expression and replace may use $1-style groups.
+func titleReplacer(find, replace string, regex bool) (func(title string) string, error) {
+	if find == "" {
+		return nil, fmt.Errorf("find is required")
+	}
+	if len(find) > maxPatternLength {
+		return nil, fmt.Errorf("find must be at most %d characters", maxPatternLength)
+	}
+	if !regex {
+		return func(title string) string { return strings.TrimSpace(strings.ReplaceAll(title, find, replace)) }, nil
+	}
+
+	re, err := regexp.Compile(find)
+	if err != nil {
+		return nil, fmt.Errorf("invalid regular expression: %v", err)
+	}
+	return func(title string) string { return strings.TrimSpace(re.ReplaceAllString(title, replace)) }, nil
+}
+
+type BulkRenameTitlesArgs struct {
+	BulkOptions
+	Collection int    `json:"collection" jsonschema:"description=Collection ID to rename in (0 for all bookmarks)"`
+	Find       string `json:"find" jsonschema:"required,description=Text or regular expression to find in titles"`
+	Replace    string `json:"replace,omitempty" jsonschema:"description=Replacement text; with regex it may use $1-style groups"`
+	Regex      bool   `json:"regex,omitempty" jsonschema:"description=Treat find as a regular expression"`
+	Apply      bool   `json:"apply,omitempty" jsonschema:"description=Rename the bookmarks (default only previews the changes)"`
+}
+
+func bulkRenameTitlesHandler(client *RaindropClient) func(ctx context.Context, args BulkRenameTitlesArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args BulkRenameTitlesArgs) (*mcp.ToolResponse, error) {
+		rename, err := titleReplacer(args.Find, args.Replace, args.Regex)
+		if err != nil {
+			return nil, err
+		}
+
+		ctx, cancel := client.bulkContext(ctx)
+		defer cancel()
+
+		items, err := client.listRaindrops(ctx, args.Collection, url.Values{}, maxExportItems)
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+
+		var ids []int
+		titles := map[int]string{}
+		renamed := map[int]string{}
+		for _, item := range items {
+			title := stringField(item, "title")
+			if newTitle := rename(title); newTitle != title && newTitle != "" {
+				id := intField(item, "_id")
+				ids = append(ids, id)
+				titles[id] = title
+				renamed[id] = newTitle
+			}
+		}
+
+		result := newBulkResult(ids, make([]error, len(ids)))
+		if args.Apply {
+			result = client.bulkApply(ctx, ids, args.StopOnError, func(ctx context.Context, id int) error {
+				_, err := client.updateRaindrop(ctx, id, map[string]interface{}{"title": renamed[id]})
+				return err
+			})
+		}
+
+		var report strings.Builder
+		for _, id := range result.Succeeded {
+			report.WriteString(fmt.Sprintf("\nBookmark %d: %q -> %q", id, titles[id], renamed[id]))
+		}
+		report.WriteString(result.FailedText())
+
+		verb := "Renamed"
+		if !args.Apply {
+			verb = "Would rename"
+		}
+
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(fmt.Sprintf("%s %d of %d bookmarks:%s", verb, len(result.Succeeded), len(items), report.String())),
+		), nil
+	}
+}
+
+// fullBookmarkFields are the fields get-bookmark-full always reports, as null
+// when Raindrop omits them.
+var fullBookmarkFields = []string{
+	"_id", "title", "link", "excerpt", "note", "tags", "type", "cover", "media",
+	"domain", "created", "lastUpdate", "important", "broken", "cache", "collection",
+}
+
+// fullBookmark returns every field of bookmark, adding the fullBookmarkFields
+// it lacks as nil so they encode as null.
+func fullBookmark(bookmark map[string]interface{}) map[string]interface{} {
+	full := map[string]interface{}{}
+	for key, value := range bookmark {
+		full[key] = value
+	}
+	for _, field := range fullBookmarkFields {
+		if _, ok := full[field]; !ok {
+			full[field] = nil
+		}
+	}
+	return full
+}
+
+type GetBookmarkFullArgs struct {
+	ID int `json:"id" jsonschema:"required,description=Bookmark ID"`
+}
+
+func getBookmarkFullHandler(client *RaindropClient) func(ctx context.Context, args GetBookmarkFullArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args GetBookmarkFullArgs) (*mcp.ToolResponse, error) {
+		if args.ID == 0 {
+			return nil, fmt.Errorf("ID is required")
+		}
+
+		bookmark, err := client.getRaindrop(ctx, args.ID)
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+
+		return jsonResponse(fullBookmark(bookmark))
+	}
+}
+
+func createBookmarkHandler(client *RaindropClient) func(ctx context.Context, args CreateBookmarkArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args CreateBookmarkArgs) (*mcp.ToolResponse, error) {
+		if args.URL == "" {
+			return nil, fmt.Errorf("URL is required")
+		}
+		if args.Type != "" {
+			if err := validateType(args.Type); err != nil {
+				return nil, err
+			}
+		}
+		// created is recorded under the idempotency key once the bookmark
+		// exists; every earlier return releases the key for a retry.
+		var created *idempotentResult
+		if args.IdempotencyKey != "" {
+			previous, replay, err := client.beginCreate(ctx, args.IdempotencyKey, time.Now())
+			if err != nil {
+				return nil, err
+			}
+			if replay {
+				return mcp.NewToolResponse(
+					mcp.NewTextContent(previous.Response + " (already created with this idempotency key)"),
+				), nil
+			}
+			defer func() {
+				client.finishCreate(args.IdempotencyKey, created, time.Now())
+			}()
+		}
+
+		link := args.URL
+		if args.ResolveRedirects {
+			link = resolveRedirects(link)
+		}
+
+		stripTracking := client.StripTracking
+		if args.StripTracking != nil {
+			stripTracking = *args.StripTracking
+		}
+		if stripTracking {
+			link = stripTrackingParams(link, client.TrackingParams)
+		}
+
+		// Prepare the request body
+		body := map[string]interface{}{
+			"link": link,
+			"tags": mergeTags(args.Tags, client.DefaultTags),
+		}
+		if args.Title != "" {
+			body["title"] = args.Title
+		}
+		if wantsParse(args.Title, args.Parse) {
+			body["pleaseParse"] = map[string]interface{}{}
+		}
+
+		if args.Type != "" {
+			body["type"] = args.Type
+		}
+
+		if args.Collection != 0 {
+			body["collection"] = map[string]interface{}{"$id": args.Collection}
+		} else {
+			body["collection"] = map[string]interface{}{"$id": 0}
+		}
+
+		bookmark, err := client.MakeRequest(ctx, "/raindrop", "POST", body)
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+
+		saved := link
+		item, _ := bookmark["item"].(map[string]interface{})
+		if stringField(item, "link") != "" {
+			saved = stringField(item, "link")
+		}
+
+		responseText := fmt.Sprintf("Bookmark created successfully: %s", saved)
+		if link != args.URL {
+			responseText += fmt.Sprintf(" (cleaned up %s to %s)", args.URL, link)
+		}
+		created = &idempotentResult{ID: intField(item, "_id"), Response: responseText}
+
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(responseText),
+		), nil
+	}
+}
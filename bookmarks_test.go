@@ -0,0 +1,213 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMergeMetadata(t *testing.T) {
+	current := map[string]interface{}{"title": "Old title", "excerpt": "", "cover": ""}
+	parsed := map[string]interface{}{
+		"title":   "New title",
+		"excerpt": "A description",
+		"media":   []interface{}{map[string]interface{}{"link": "https://example.com/cover.png"}},
+	}
+
+	got := mergeMetadata(current, parsed, false)
+	expected := map[string]interface{}{"excerpt": "A description", "cover": "https://example.com/cover.png"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+
+	got = mergeMetadata(current, parsed, true)
+	expected["title"] = "New title"
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v with force, got %v", expected, got)
+	}
+
+	if got := mergeMetadata(parsed, map[string]interface{}{"title": ""}, true); len(got) != 0 {
+		t.Errorf("Expected empty parsed fields to be ignored, got %v", got)
+	}
+}
+
+func TestSwapTarget(t *testing.T) {
+	tests := []struct{ current, expected int }{
+		{current: 1, expected: 2},
+		{current: 2, expected: 1},
+		{current: 3, expected: 1},
+	}
+	for _, test := range tests {
+		if got := swapTarget(test.current, 1, 2); got != test.expected {
+			t.Errorf("swapTarget(%d) = %d, expected %d", test.current, got, test.expected)
+		}
+	}
+}
+
+func TestMergeBookmarkFields(t *testing.T) {
+	keep := map[string]interface{}{"tags": []interface{}{"go"}, "excerpt": "", "note": "mine"}
+	merge := map[string]interface{}{"tags": []interface{}{"Go", "api"}, "excerpt": "From the dupe", "note": "theirs"}
+
+	got := mergeBookmarkFields(keep, merge)
+	expected := map[string]interface{}{
+		"tags":    []string{"go", "api"},
+		"excerpt": "From the dupe",
+		"note":    "mine\n\ntheirs",
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+
+	if got := mergeBookmarkFields(keep, map[string]interface{}{"tags": []interface{}{"GO"}, "note": "mine"}); len(got) != 0 {
+		t.Errorf("Expected nothing to merge, got %v", got)
+	}
+}
+
+func TestBookmarkCopy(t *testing.T) {
+	bookmark := map[string]interface{}{
+		"_id":        float64(42),
+		"link":       "https://go.dev",
+		"title":      "Go",
+		"note":       "docs",
+		"important":  true,
+		"tags":       []interface{}{"lang"},
+		"collection": map[string]interface{}{"$id": float64(1)},
+	}
+
+	expected := map[string]interface{}{
+		"link":       "https://go.dev",
+		"title":      "Go",
+		"note":       "docs",
+		"important":  true,
+		"tags":       []string{"lang", "linked:42"},
+		"collection": map[string]interface{}{"$id": 7},
+	}
+	if got := bookmarkCopy(bookmark, 7); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+}
+
+func TestTitleReplacer(t *testing.T) {
+	literal, err := titleReplacer(" (1)", "", false)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := literal("Report (1)"); got != "Report" {
+		t.Errorf("Expected literal replacement, got %q", got)
+	}
+
+	regex, err := titleReplacer(`^(.+) \| Medium$`, "$1", true)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := regex("Go tips | Medium"); got != "Go tips" {
+		t.Errorf("Expected regex replacement, got %q", got)
+	}
+	if got := regex("Unrelated"); got != "Unrelated" {
+		t.Errorf("Expected title unchanged, got %q", got)
+	}
+
+	for _, find := range []string{"", "(unclosed", strings.Repeat("a", maxPatternLength+1)} {
+		if _, err := titleReplacer(find, "", true); err == nil {
+			t.Errorf("Expected error for pattern %.20q", find)
+		}
+	}
+}
+
+func TestFullBookmark(t *testing.T) {
+	bookmark := map[string]interface{}{"_id": float64(1), "link": "https://go.dev", "sort": float64(5)}
+
+	full := fullBookmark(bookmark)
+	if full["link"] != "https://go.dev" || full["sort"] != float64(5) {
+		t.Errorf("Expected stored fields to be kept, got %v", full)
+	}
+	for _, field := range fullBookmarkFields {
+		if _, ok := full[field]; !ok {
+			t.Errorf("Expected %s to be present", field)
+		}
+	}
+	if full["note"] != nil {
+		t.Errorf("Expected missing note to be nil, got %v", full["note"])
+	}
+	if _, ok := bookmark["note"]; ok {
+		t.Error("Expected the original bookmark to be left alone")
+	}
+}
+
+func TestWantsParse(t *testing.T) {
+	yes, no := true, false
+	tests := []struct {
+		title    string
+		parse    *bool
+		expected bool
+	}{
+		{"", nil, true},
+		{"  ", nil, true},
+		{"Given title", nil, false},
+		{"Given title", &yes, true},
+		{"", &no, false},
+	}
+	for _, test := range tests {
+		if got := wantsParse(test.title, test.parse); got != test.expected {
+			t.Errorf("wantsParse(%q, %v) = %v, expected %v", test.title, test.parse, got, test.expected)
+		}
+	}
+}
+
+func TestFileInMultipleReportsPartialFailure(t *testing.T) {
+	batches := 0
+	var tagged []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/raindrop/1":
+			w.Write([]byte(`{"item": {"_id": 1, "link": "https://example.com", "collection": {"$id": 500}}}`))
+		case r.Method == "POST" && r.URL.Path == "/raindrops":
+			batches++
+			if batches == 2 {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			var body struct {
+				Items []map[string]interface{} `json:"items"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			for i, item := range body.Items {
+				item["_id"] = 1000 + i
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"items": body.Items})
+		case r.Method == "PUT" && r.URL.Path == "/raindrop/1":
+			var body struct {
+				Tags []string `json:"tags"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			tagged = body.Tags
+			w.Write([]byte(`{"item": {}}`))
+		default:
+			t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	collections := make([]int, raindropBatchSize+1)
+	for i := range collections {
+		collections[i] = i + 1
+	}
+	client := &RaindropClient{Token: "test-token", BaseURL: server.URL}
+	resp, err := fileInMultipleHandler(client)(context.Background(), FileInMultipleArgs{ID: 1, Collections: collections})
+	if err != nil {
+		t.Fatalf("Expected the created copies to be reported, got error %v", err)
+	}
+	text := resp.Content[0].TextContent.Text
+	if !strings.Contains(text, "in 100 of 101 more collections") || !strings.Contains(text, "Collection 1: bookmark 1000") ||
+		!strings.Contains(text, "Collection 101: not filed") || !strings.Contains(text, "Failed (1):") {
+		t.Errorf("Expected the copies and the failure to be reported, got %q", text)
+	}
+	if !reflect.DeepEqual(tagged, []string{"linked:1"}) {
+		t.Errorf("Expected the original to be tagged, got %v", tagged)
+	}
+}
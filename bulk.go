@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// errBulkStopped marks items a stop-on-error operation did not attempt.
+var errBulkStopped = errors.New("skipped after an earlier failure")
+
+// BulkResult is the outcome of a bulk operation over IDs: which items
+// succeeded and, for each failed item, why.
+type BulkResult struct {
+	Succeeded []int         `json:"succeeded"`
+	Failed    []BulkFailure `json:"failed"`
+	// Skipped lists the items not attempted because a stop-on-error
+	// operation had already failed.
+	Skipped []int `json:"skipped,omitempty"`
+}
+
+// BulkFailure is an item a bulk operation could not process.
+type BulkFailure struct {
+	ID int `json:"id,omitempty"`
+	// Item identifies an item that has no ID yet, such as the URL of a
+	// bookmark that could not be created.
+	Item string `json:"item,omitempty"`
+	Err  string `json:"error"`
+}
+
+// label is how a failure is named in text output: its ID, or its Item when
+// it has none.
+func (f BulkFailure) label() string {
+	if f.Item != "" {
+		return f.Item
+	}
+	return strconv.Itoa(f.ID)
+}
+
+// newBulkResult pairs IDs with the per-item errors of a bulk operation.
+func newBulkResult(ids []int, errs []error) BulkResult {
+	result := BulkResult{Succeeded: []int{}, Failed: []BulkFailure{}}
+	for i, id := range ids {
+		if errs[i] != nil {
+			result.Failed = append(result.Failed, BulkFailure{ID: id, Err: errs[i].Error()})
+			continue
+		}
+		result.Succeeded = append(result.Succeeded, id)
+	}
+	return result
+}
+
+// bulkApply calls fn for every ID and aggregates the outcome per ID. By
+// default it is best-effort: IDs run through the concurrency limiter and every
+// failure is collected. With stopOnError, IDs run one at a time in order and
+// the first failure ends the operation, leaving the rest Skipped.
+func (r *RaindropClient) bulkApply(ctx context.Context, ids []int, stopOnError bool, fn func(ctx context.Context, id int) error) BulkResult {
+	if !stopOnError {
+		errs := r.forEachConcurrent(ctx, len(ids), func(ctx context.Context, i int) error {
+			return fn(ctx, ids[i])
+		})
+		return newBulkResult(ids, errs)
+	}
+
+	result := newBulkResult(nil, nil)
+	for i, id := range ids {
+		err := ctx.Err()
+		if err == nil {
+			err = fn(ctx, id)
+		}
+		if err != nil {
+			result.Failed = append(result.Failed, BulkFailure{ID: id, Err: err.Error()})
+			result.Skipped = append([]int{}, ids[i+1:]...)
+			break
+		}
+		result.Succeeded = append(result.Succeeded, id)
+	}
+	return result
+}
+
+// merge appends the outcome of another part of the same bulk operation.
+func (b *BulkResult) merge(other BulkResult) {
+	b.Succeeded = append(b.Succeeded, other.Succeeded...)
+	b.Failed = append(b.Failed, other.Failed...)
+	b.Skipped = append(b.Skipped, other.Skipped...)
+}
+
+// Text renders the result for a text response: the succeeded IDs on one line,
+// then one line per failure.
+func (b BulkResult) Text() string {
+	if len(b.Succeeded) == 0 {
+		return b.FailedText()
+	}
+	return fmt.Sprintf("\nSucceeded (%d): %s", len(b.Succeeded), joinInts(b.Succeeded)) + b.FailedText()
+}
+
+// FailedText renders only the failures and skipped items, for tools that
+// already list what they changed.
+func (b BulkResult) FailedText() string {
+	var text strings.Builder
+	if len(b.Failed) > 0 {
+		text.WriteString(fmt.Sprintf("\nFailed (%d):", len(b.Failed)))
+		for _, failure := range b.Failed {
+			text.WriteString(fmt.Sprintf("\n  %s: %s", failure.label(), failure.Err))
+		}
+	}
+	if len(b.Skipped) > 0 {
+		text.WriteString(fmt.Sprintf("\nSkipped after the failure (%d): %s", len(b.Skipped), joinInts(b.Skipped)))
+	}
+	return text.String()
+}
+
+// joinInts renders IDs as a comma-separated list.
+func joinInts(ids []int) string {
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = strconv.Itoa(id)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// BulkOptions are the arguments shared by bulk tools.
+type BulkOptions struct {
+	StopOnError bool `json:"stopOnError,omitempty" jsonschema:"description=Stop at the first failed item and skip the rest instead of continuing best-effort"`
+}
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBulkApplyPartialFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/raindrop/2" || r.URL.Path == "/raindrop/4" {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"result":false}`))
+			return
+		}
+		w.Write([]byte(`{"result":true,"item":{}}`))
+	}))
+	defer server.Close()
+
+	client := &RaindropClient{Token: "test-token", BaseURL: server.URL}
+	result := client.bulkApply(context.Background(), []int{1, 2, 3, 4, 5}, false, func(ctx context.Context, id int) error {
+		_, err := client.updateRaindrop(ctx, id, map[string]interface{}{"important": true})
+		return err
+	})
+
+	if !reflect.DeepEqual(result.Succeeded, []int{1, 3, 5}) {
+		t.Errorf("Expected 1, 3 and 5 to succeed, got %v", result.Succeeded)
+	}
+	if len(result.Failed) != 2 || result.Failed[0].ID != 2 || result.Failed[1].ID != 4 {
+		t.Fatalf("Expected 2 and 4 to fail, got %+v", result.Failed)
+	}
+	for _, failure := range result.Failed {
+		if !strings.Contains(failure.Err, "500") {
+			t.Errorf("Expected the status in the error for %d, got %q", failure.ID, failure.Err)
+		}
+	}
+}
+
+func TestBulkApplyStopOnError(t *testing.T) {
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = append(requested, r.URL.Path)
+		if r.URL.Path == "/raindrop/3" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(`{"result":true,"item":{}}`))
+	}))
+	defer server.Close()
+
+	client := &RaindropClient{Token: "test-token", BaseURL: server.URL}
+	update := func(ctx context.Context, id int) error {
+		_, err := client.updateRaindrop(ctx, id, map[string]interface{}{"important": true})
+		return err
+	}
+
+	result := client.bulkApply(context.Background(), []int{1, 2, 3, 4, 5}, true, update)
+	if !reflect.DeepEqual(result.Succeeded, []int{1, 2}) || !reflect.DeepEqual(result.Skipped, []int{4, 5}) {
+		t.Errorf("Expected 1 and 2 to succeed and 4 and 5 to be skipped, got %+v", result)
+	}
+	if len(result.Failed) != 1 || result.Failed[0].ID != 3 {
+		t.Errorf("Expected 3 to fail, got %+v", result.Failed)
+	}
+	if !reflect.DeepEqual(requested, []string{"/raindrop/1", "/raindrop/2", "/raindrop/3"}) {
+		t.Errorf("Expected no requests after the failure, got %v", requested)
+	}
+	if text := result.Text(); !strings.Contains(text, "Skipped after the failure (2): 4, 5") {
+		t.Errorf("Expected skipped items in text, got %q", text)
+	}
+
+	result = client.bulkApply(context.Background(), []int{1, 2, 3, 4, 5}, false, update)
+	if !reflect.DeepEqual(result.Succeeded, []int{1, 2, 4, 5}) || len(result.Failed) != 1 || len(result.Skipped) != 0 {
+		t.Errorf("Expected best-effort to continue past 3, got %+v", result)
+	}
+}
+
+func TestCreateRaindropsStopOnError(t *testing.T) {
+	batches := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		batches++
+		if batches == 2 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(`{"result":true,"items":[]}`))
+	}))
+	defer server.Close()
+
+	client := &RaindropClient{Token: "test-token", BaseURL: server.URL}
+	items := make([]map[string]interface{}, 3*raindropBatchSize)
+	for i := range items {
+		items[i] = map[string]interface{}{"link": "https://example.com"}
+	}
+
+	_, result := client.createRaindrops(context.Background(), items, true)
+	if batches != 2 {
+		t.Errorf("Expected the third batch not to be sent, got %d requests", batches)
+	}
+	if len(result.Failed) != 2*raindropBatchSize {
+		t.Fatalf("Expected the failed batch and the skipped rest to fail, got %d failures", len(result.Failed))
+	}
+	if last := result.Failed[len(result.Failed)-1]; last.Item != "https://example.com" || last.Err != errBulkStopped.Error() {
+		t.Errorf("Expected skipped items to be named by link and fail as stopped, got %+v", last)
+	}
+
+	batches = 0
+	_, result = client.createRaindrops(context.Background(), items, false)
+	if batches != 3 || len(result.Failed) != raindropBatchSize {
+		t.Errorf("Expected best-effort to send every batch, got %d requests and %d failures", batches, len(result.Failed))
+	}
+}
+
+func TestBulkResultSerialization(t *testing.T) {
+	result := BulkResult{
+		Succeeded: []int{1, 3},
+		Failed:    []BulkFailure{{ID: 2, Err: "not found"}},
+	}
+
+	text := result.Text()
+	if !strings.Contains(text, "Succeeded (2): 1, 3") || !strings.Contains(text, "Failed (1):\n  2: not found") {
+		t.Errorf("Unexpected text: %q", text)
+	}
+
+	encoded, err := json.Marshal(result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"succeeded":[1,3],"failed":[{"id":2,"error":"not found"}]}`
+	if string(encoded) != expected {
+		t.Errorf("Expected %s, got %s", expected, encoded)
+	}
+
+	empty, _ := json.Marshal(newBulkResult(nil, nil))
+	if string(empty) != `{"succeeded":[],"failed":[]}` {
+		t.Errorf("Expected empty arrays, got %s", empty)
+	}
+}
+
+func TestBulkRemoveTagReportsUntaggedApart(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/raindrop/1":
+			w.Write([]byte(`{"item": {"_id": 1, "tags": ["go", "old"]}}`))
+		case r.Method == "GET" && r.URL.Path == "/raindrop/2":
+			w.Write([]byte(`{"item": {"_id": 2, "tags": ["go"]}}`))
+		case r.Method == "PUT" && r.URL.Path == "/raindrop/1":
+			w.Write([]byte(`{"item": {"_id": 1, "tags": ["go"]}}`))
+		default:
+			t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := &RaindropClient{Token: "test-token", BaseURL: server.URL}
+	resp, err := bulkRemoveTagHandler(client)(context.Background(), BulkRemoveTagArgs{IDs: []int{1, 2}, Tag: "old", JSON: true})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var output struct {
+		Result    BulkResult `json:"result"`
+		NotTagged []int      `json:"notTagged"`
+	}
+	if err := json.Unmarshal([]byte(resp.Content[0].TextContent.Text), &output); err != nil {
+		t.Fatalf("Unexpected JSON: %v", err)
+	}
+	if !reflect.DeepEqual(output.Result.Succeeded, []int{1}) || !reflect.DeepEqual(output.NotTagged, []int{2}) {
+		t.Errorf("Expected 1 succeeded and 2 not tagged, got %+v", output)
+	}
+
+	resp, err = bulkRemoveTagHandler(client)(context.Background(), BulkRemoveTagArgs{IDs: []int{1, 2}, Tag: "old"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	text := resp.Content[0].TextContent.Text
+	if !strings.Contains(text, "from 1 of 2 bookmarks (1 did not have it)") || !strings.Contains(text, "Succeeded (1): 1\n") {
+		t.Errorf("Expected the text to match the JSON, got %q", text)
+	}
+}
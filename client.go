@@ -0,0 +1,389 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const RaindropAPIBase = "https://api.raindrop.io/rest/v1"
+
+const (
+	// DefaultTimeout bounds a single Raindrop API request.
+	DefaultTimeout = 30 * time.Second
+	// DefaultBulkTimeout bounds a whole bulk or export operation.
+	DefaultBulkTimeout = 5 * time.Minute
+	// DefaultConcurrency is how many requests bulk tools run in parallel.
+	DefaultConcurrency = 4
+)
+
+// RaindropAPI client
+//
+// A RaindropClient is safe for concurrent use by multiple tool calls. Its
+// configuration fields must be set before the client is shared and not
+// changed afterwards; the state that changes while serving (the rate-limit
+// quota, idempotency keys and the active account) is guarded by mutexes and
+// only accessed through methods.
+type RaindropClient struct {
+	// Token authenticates requests as the active account. Once the client is
+	// shared, read it with activeAccount and change it with switchAccount.
+	Token string
+	// Account names the active account, one of Accounts. It is guarded like Token.
+	Account string
+	// Accounts maps each configured account name to its token.
+	Accounts map[string]string
+	// BaseURL overrides RaindropAPIBase, e.g. to point tests at a mock server.
+	BaseURL string
+	// Timeout applies to requests whose context has no deadline of its own.
+	Timeout time.Duration
+	// BulkTimeout applies to bulk and export operations as a whole.
+	BulkTimeout time.Duration
+	// DefaultTags are added to every bookmark created with create-bookmark.
+	DefaultTags []string
+	// Concurrency limits how many requests a bulk tool runs in parallel.
+	Concurrency int
+	// StripTracking is the create-bookmark default for removing tracking parameters.
+	StripTracking bool
+	// TrackingParams are the query parameter prefixes treated as tracking.
+	TrackingParams []string
+	// IdempotencyTTL is how long create-bookmark remembers an idempotency key.
+	IdempotencyTTL time.Duration
+	// BackupDir is the directory backup-to-file may write to; empty disables it.
+	BackupDir string
+
+	// RequestHook, if set, is called with every outgoing API request just
+	// before it is sent, e.g. to add headers for tracing. It runs before the
+	// Authorization header is set, so it cannot remove or replace the token.
+	RequestHook func(*http.Request)
+	// ResponseHook, if set, is called with every API response that arrives,
+	// including error statuses, e.g. to record metrics. It must not read or
+	// close the body.
+	ResponseHook func(*http.Response)
+
+	accountMu sync.RWMutex
+
+	rateLimitMu sync.Mutex
+	rateLimit   RateLimit
+
+	idempotencyMu sync.Mutex
+	idempotency   map[string]*idempotentEntry
+}
+
+// RateLimit is the quota Raindrop reported on the most recent response.
+type RateLimit struct {
+	Limit     int
+	Remaining int
+	Reset     time.Time
+	// Seen is false until a response carrying rate-limit headers arrives.
+	Seen bool
+}
+
+// recordRateLimit stores the X-RateLimit-* headers of a response, if present.
+func (r *RaindropClient) recordRateLimit(header http.Header) {
+	remaining, err := strconv.Atoi(header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return
+	}
+	limit, _ := strconv.Atoi(header.Get("X-RateLimit-Limit"))
+
+	var reset time.Time
+	if seconds, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		reset = time.Unix(seconds, 0)
+	}
+
+	r.rateLimitMu.Lock()
+	defer r.rateLimitMu.Unlock()
+	r.rateLimit = RateLimit{Limit: limit, Remaining: remaining, Reset: reset, Seen: true}
+}
+
+// RateLimit returns the most recently observed rate-limit quota.
+func (r *RaindropClient) RateLimit() RateLimit {
+	r.rateLimitMu.Lock()
+	defer r.rateLimitMu.Unlock()
+	return r.rateLimit
+}
+
+func NewRaindropClient() (*RaindropClient, error) {
+	accounts := accountsFromEnv(os.Environ())
+	if token := os.Getenv("RAINDROP_TOKEN"); token != "" {
+		accounts[defaultAccountName] = token
+	}
+	if len(accounts) == 0 {
+		return nil, errors.New("RAINDROP_TOKEN is not set")
+	}
+	account := defaultAccountName
+	if name := os.Getenv("RAINDROP_ACCOUNT"); name != "" {
+		account = strings.ToLower(name)
+	}
+	if _, ok := accounts[account]; !ok {
+		if account == defaultAccountName {
+			return nil, errors.New("RAINDROP_TOKEN is not set; set RAINDROP_ACCOUNT to choose one of the RAINDROP_TOKEN_* accounts")
+		}
+		return nil, fmt.Errorf("RAINDROP_ACCOUNT names %q, but RAINDROP_TOKEN_%s is not set", account, strings.ToUpper(account))
+	}
+
+	timeout, err := durationFromEnv("RAINDROP_TIMEOUT", DefaultTimeout)
+	if err != nil {
+		return nil, err
+	}
+	bulkTimeout, err := durationFromEnv("RAINDROP_BULK_TIMEOUT", DefaultBulkTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	concurrency, err := intFromEnv("RAINDROP_CONCURRENCY", DefaultConcurrency)
+	if err != nil {
+		return nil, err
+	}
+
+	stripTracking, err := boolFromEnv("RAINDROP_STRIP_TRACKING", false)
+	if err != nil {
+		return nil, err
+	}
+	idempotencyTTL, err := durationFromEnv("RAINDROP_IDEMPOTENCY_TTL", DefaultIdempotencyTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	trackingParams := defaultTrackingParams
+	if value := os.Getenv("RAINDROP_TRACKING_PARAMS"); value != "" {
+		trackingParams = splitList(value)
+	}
+
+	return &RaindropClient{
+		Token:          accounts[account],
+		Account:        account,
+		Accounts:       accounts,
+		Timeout:        timeout,
+		BulkTimeout:    bulkTimeout,
+		DefaultTags:    splitList(os.Getenv("RAINDROP_DEFAULT_TAGS")),
+		Concurrency:    concurrency,
+		StripTracking:  stripTracking,
+		TrackingParams: trackingParams,
+		IdempotencyTTL: idempotencyTTL,
+		BackupDir:      os.Getenv("RAINDROP_BACKUP_DIR"),
+	}, nil
+}
+
+// boolFromEnv reads a boolean such as "true" or "0" from the environment.
+func boolFromEnv(name string, fallback bool) (bool, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback, nil
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("%s must be true or false, got %q", name, value)
+	}
+	return b, nil
+}
+
+// intFromEnv reads a positive integer from the environment.
+func intFromEnv(name string, fallback int) (int, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("%s must be a positive integer, got %q", name, value)
+	}
+	return n, nil
+}
+
+// durationFromEnv reads a duration such as "45s" or "10m" from the environment.
+func durationFromEnv(name string, fallback time.Duration) (time.Duration, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("%s must be a positive duration such as 30s, got %q", name, value)
+	}
+	return d, nil
+}
+
+// splitList splits a comma-separated value into trimmed, non-empty entries.
+func splitList(value string) []string {
+	return dedupeStrings(strings.Split(value, ","))
+}
+
+// bulkContext derives the context for a bulk or export operation. Every request
+// made with it shares the BulkTimeout deadline instead of the per-request Timeout.
+func (r *RaindropClient) bulkContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	timeout := r.BulkTimeout
+	if timeout <= 0 {
+		timeout = DefaultBulkTimeout
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// doRequest is the transport core shared by the JSON and multipart helpers. It
+// applies the client's Timeout when ctx has no deadline, injects the
+// Authorization header after the caller's headers, and turns non-2xx responses
+// into errors. The response body is read fully and returned alongside the response.
+func (r *RaindropClient) doRequest(ctx context.Context, method string, endpoint string, headers http.Header, body io.Reader) (*http.Response, []byte, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		timeout := r.Timeout
+		if timeout <= 0 {
+			timeout = DefaultTimeout
+		}
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	baseURL := r.BaseURL
+	if baseURL == "" {
+		baseURL = RaindropAPIBase
+	}
+	url := fmt.Sprintf("%s%s", baseURL, endpoint)
+
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for key, values := range headers {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	if r.RequestHook != nil {
+		r.RequestHook(req)
+	}
+	_, token := r.activeAccount()
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	if r.ResponseHook != nil {
+		r.ResponseHook(resp)
+	}
+
+	r.recordRateLimit(resp.Header)
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp, respBody, apiError(resp, respBody)
+	}
+
+	return resp, respBody, nil
+}
+
+// apiError builds an error for a failed response, including Raindrop's
+// errorMessage when the body carries one.
+func apiError(resp *http.Response, body []byte) error {
+	var payload struct {
+		ErrorMessage string `json:"errorMessage"`
+		Error        string `json:"error"`
+	}
+	if json.Unmarshal(body, &payload) == nil {
+		if payload.ErrorMessage != "" {
+			return fmt.Errorf("Raindrop API error: %s: %s", resp.Status, payload.ErrorMessage)
+		}
+		if payload.Error != "" {
+			return fmt.Errorf("Raindrop API error: %s: %s", resp.Status, payload.Error)
+		}
+	}
+	return fmt.Errorf("Raindrop API error: %s", resp.Status)
+}
+
+// MakeRequest sends a JSON request to the Raindrop API and decodes the JSON response.
+func (r *RaindropClient) MakeRequest(ctx context.Context, endpoint string, method string, body interface{}) (map[string]interface{}, error) {
+	// Bodiless requests (GET, DELETE, ...) send neither a body nor a Content-Type.
+	headers := http.Header{}
+	var reqBody io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reqBody = bytes.NewReader(encoded)
+		headers.Set("Content-Type", "application/json")
+	}
+
+	resp, respBody, err := r.doRequest(ctx, method, endpoint, headers, reqBody)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeJSON(resp, respBody)
+}
+
+// maxBodySnippet is how much of an undecodable response body is quoted in errors.
+const maxBodySnippet = 200
+
+// decodeJSON decodes a successful response body. When the body is not JSON, e.g.
+// an HTML error page served during an outage, the error names the content type
+// and quotes the start of the body.
+func decodeJSON(resp *http.Response, body []byte) (map[string]interface{}, error) {
+	var result map[string]interface{}
+	if err := json.Unmarshal(body, &result); err != nil {
+		contentType := resp.Header.Get("Content-Type")
+		if contentType == "" {
+			contentType = "no content type"
+		}
+
+		snippet := strings.Join(strings.Fields(string(body)), " ")
+		if len(snippet) > maxBodySnippet {
+			snippet = snippet[:maxBodySnippet] + "..."
+		}
+		return nil, fmt.Errorf("expected JSON, got %s from Raindrop (%s): %v: %q", contentType, resp.Status, err, snippet)
+	}
+	return result, nil
+}
+
+// MakeMultipartRequest uploads a file as multipart/form-data under fieldName,
+// together with any extra form fields, and decodes the JSON response.
+func (r *RaindropClient) MakeMultipartRequest(ctx context.Context, endpoint string, method string, fieldName string, fileName string, file io.Reader, fields map[string]string) (map[string]interface{}, error) {
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+
+	for key, value := range fields {
+		if err := writer.WriteField(key, value); err != nil {
+			return nil, err
+		}
+	}
+
+	part, err := writer.CreateFormFile(fieldName, fileName)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.Copy(part, file); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	headers := http.Header{}
+	headers.Set("Content-Type", writer.FormDataContentType())
+
+	resp, respBody, err := r.doRequest(ctx, method, endpoint, headers, &buf)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeJSON(resp, respBody)
+}
@@ -0,0 +1,368 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestDoRequestHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			t.Errorf("Expected Authorization header with token, got: %s", r.Header.Get("Authorization"))
+		}
+		if r.Header.Get("X-Custom") != "value" {
+			t.Errorf("Expected X-Custom header, got: %s", r.Header.Get("X-Custom"))
+		}
+		if r.URL.Path != "/rest/v1/test" {
+			t.Errorf("Unexpected path: %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"result": true}`))
+	}))
+	defer server.Close()
+
+	client := &RaindropClient{Token: "test-token", BaseURL: server.URL + "/rest/v1"}
+
+	headers := http.Header{}
+	headers.Set("X-Custom", "value")
+	headers.Set("Authorization", "Bearer other-token")
+
+	resp, body, err := client.doRequest(context.Background(), "GET", "/test", headers, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", resp.StatusCode)
+	}
+	if string(body) != `{"result": true}` {
+		t.Errorf("Unexpected body: %s", body)
+	}
+}
+
+func TestDoRequestErrorDecoding(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/message":
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"result": false, "errorMessage": "Incorrect collection"}`))
+		case "/error":
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error": "invalid_token"}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`<html>oops</html>`))
+		}
+	}))
+	defer server.Close()
+
+	client := &RaindropClient{Token: "test-token", BaseURL: server.URL}
+
+	tests := map[string]string{
+		"/message": "Raindrop API error: 400 Bad Request: Incorrect collection",
+		"/error":   "Raindrop API error: 401 Unauthorized: invalid_token",
+		"/html":    "Raindrop API error: 500 Internal Server Error",
+	}
+	for endpoint, expected := range tests {
+		_, _, err := client.doRequest(context.Background(), "GET", endpoint, nil, nil)
+		if err == nil || err.Error() != expected {
+			t.Errorf("Expected error %q for %s, got %v", expected, endpoint, err)
+		}
+	}
+}
+
+func TestMakeMultipartRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			t.Errorf("Expected Authorization header with token, got: %s", r.Header.Get("Authorization"))
+		}
+		if !strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+			t.Errorf("Expected multipart content type, got: %s", r.Header.Get("Content-Type"))
+		}
+
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			t.Fatalf("Error reading form file: %v", err)
+		}
+		content, _ := io.ReadAll(file)
+		if header.Filename != "notes.txt" || string(content) != "hello" {
+			t.Errorf("Unexpected file %s with content %q", header.Filename, content)
+		}
+		if r.FormValue("collectionId") != "42" {
+			t.Errorf("Expected collectionId field, got %q", r.FormValue("collectionId"))
+		}
+
+		w.Write([]byte(`{"result": true}`))
+	}))
+	defer server.Close()
+
+	client := &RaindropClient{Token: "test-token", BaseURL: server.URL}
+	result, err := client.MakeMultipartRequest(context.Background(), "/raindrop/file", "PUT", "file", "notes.txt",
+		strings.NewReader("hello"), map[string]string{"collectionId": "42"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result["result"] != true {
+		t.Errorf("Unexpected result: %v", result)
+	}
+}
+
+func TestMakeRequestNonJSONBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("<html>\n  <body>Service Unavailable</body>\n</html>"))
+	}))
+	defer server.Close()
+
+	client := &RaindropClient{Token: "test-token", BaseURL: server.URL}
+	_, err := client.MakeRequest(context.Background(), "/raindrops/0", "GET", nil)
+	if err == nil {
+		t.Fatal("Expected error for HTML body, got nil")
+	}
+	for _, expected := range []string{"expected JSON, got text/html; charset=utf-8 from Raindrop", "<html> <body>Service Unavailable</body> </html>"} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("Expected error to contain %q, got: %v", expected, err)
+		}
+	}
+}
+
+func TestDecodeJSONTruncatesSnippet(t *testing.T) {
+	resp := &http.Response{Status: "200 OK", Header: http.Header{}}
+	_, err := decodeJSON(resp, []byte(strings.Repeat("x", maxBodySnippet*2)))
+	if err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+	if !strings.Contains(err.Error(), "no content type") || !strings.Contains(err.Error(), strings.Repeat("x", maxBodySnippet)+"...") {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if strings.Contains(err.Error(), strings.Repeat("x", maxBodySnippet+1)) {
+		t.Errorf("Expected snippet to be truncated, got: %v", err)
+	}
+}
+
+// newSlowServer returns a server that answers after delay unless the client goes away first.
+func newSlowServer(delay time.Duration) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(delay):
+			w.Write([]byte(`{"result": true}`))
+		case <-r.Context().Done():
+		}
+	}))
+}
+
+func TestMakeRequestCancelledContext(t *testing.T) {
+	server := newSlowServer(2 * time.Second)
+	defer server.Close()
+
+	client := &RaindropClient{Token: "test-token", BaseURL: server.URL}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	start := time.Now()
+	_, err := client.MakeRequest(ctx, "/test", "GET", nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Expected cancelled request to return quickly, took %v", elapsed)
+	}
+}
+
+func TestMakeRequestDeadline(t *testing.T) {
+	server := newSlowServer(2 * time.Second)
+	defer server.Close()
+
+	client := &RaindropClient{Token: "test-token", BaseURL: server.URL, Timeout: time.Hour}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.MakeRequest(ctx, "/test", "GET", nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected caller's deadline to win over client Timeout, took %v", elapsed)
+	}
+}
+
+func TestMakeRequestInjectsTimeout(t *testing.T) {
+	server := newSlowServer(2 * time.Second)
+	defer server.Close()
+
+	client := &RaindropClient{Token: "test-token", BaseURL: server.URL, Timeout: 50 * time.Millisecond}
+
+	start := time.Now()
+	_, err := client.MakeRequest(context.Background(), "/test", "GET", nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded from injected timeout, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected injected timeout to cut the request short, took %v", elapsed)
+	}
+}
+
+func TestRecordRateLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "120")
+		w.Header().Set("X-RateLimit-Remaining", "87")
+		w.Header().Set("X-RateLimit-Reset", "1700000000")
+		w.Write([]byte(`{"result": true}`))
+	}))
+	defer server.Close()
+
+	client := &RaindropClient{Token: "test-token", BaseURL: server.URL}
+	if client.RateLimit().Seen {
+		t.Fatal("Expected no rate limit before any request")
+	}
+
+	if _, err := client.MakeRequest(context.Background(), "/user", "GET", nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	rateLimit := client.RateLimit()
+	if !rateLimit.Seen || rateLimit.Limit != 120 || rateLimit.Remaining != 87 || rateLimit.Reset.Unix() != 1700000000 {
+		t.Errorf("Unexpected rate limit: %+v", rateLimit)
+	}
+}
+
+func TestMakeRequestBodilessGET(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if len(body) != 0 {
+			t.Errorf("Expected no body, got %q", body)
+		}
+		if r.ContentLength > 0 {
+			t.Errorf("Expected no content length, got %d", r.ContentLength)
+		}
+		if ct := r.Header.Get("Content-Type"); ct != "" {
+			t.Errorf("Expected no Content-Type, got %q", ct)
+		}
+		w.Write([]byte(`{"result": true}`))
+	}))
+	defer server.Close()
+
+	client := &RaindropClient{Token: "test-token", BaseURL: server.URL}
+	for _, method := range []string{"GET", "DELETE"} {
+		if _, err := client.MakeRequest(context.Background(), "/raindrop/1", method, nil); err != nil {
+			t.Errorf("Unexpected error for %s: %v", method, err)
+		}
+	}
+}
+
+func TestMakeRequestJSONBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("Expected JSON Content-Type, got %q", ct)
+		}
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `{"title":"Go"}` {
+			t.Errorf("Unexpected body: %q", body)
+		}
+		w.Write([]byte(`{"result": true}`))
+	}))
+	defer server.Close()
+
+	client := &RaindropClient{Token: "test-token", BaseURL: server.URL}
+	if _, err := client.MakeRequest(context.Background(), "/raindrop", "POST", map[string]string{"title": "Go"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+func TestRequestAndResponseHooks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Trace-Id") != "trace-1" {
+			t.Errorf("Expected header from the request hook, got %q", r.Header.Get("X-Trace-Id"))
+		}
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			t.Errorf("Expected the hook to be unable to change Authorization, got %q", r.Header.Get("Authorization"))
+		}
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"result": true}`))
+	}))
+	defer server.Close()
+
+	var statuses []int
+	client := &RaindropClient{
+		Token:   "test-token",
+		BaseURL: server.URL,
+		RequestHook: func(req *http.Request) {
+			req.Header.Set("X-Trace-Id", "trace-1")
+			req.Header.Del("Authorization")
+			req.Header.Set("Authorization", "Bearer hijacked")
+		},
+		ResponseHook: func(resp *http.Response) {
+			statuses = append(statuses, resp.StatusCode)
+		},
+	}
+
+	if _, err := client.MakeRequest(context.Background(), "/ok", "GET", nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := client.MakeRequest(context.Background(), "/missing", "GET", nil); err == nil {
+		t.Fatal("Expected error for 404")
+	}
+	if len(statuses) != 2 || statuses[0] != http.StatusOK || statuses[1] != http.StatusNotFound {
+		t.Errorf("Expected the response hook to see both responses, got %v", statuses)
+	}
+}
+
+// TestConcurrentClientUse exercises the client's shared state from many
+// goroutines at once; run it with -race to check the locking.
+func TestConcurrentClientUse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "Bearer token-a" && auth != "Bearer token-b" {
+			t.Errorf("Unexpected Authorization header: %q", auth)
+		}
+		w.Header().Set("X-RateLimit-Limit", "120")
+		w.Header().Set("X-RateLimit-Remaining", "100")
+		w.Write([]byte(`{"result": true}`))
+	}))
+	defer server.Close()
+
+	client := &RaindropClient{
+		Token:    "token-a",
+		Account:  "a",
+		Accounts: map[string]string{"a": "token-a", "b": "token-b"},
+		BaseURL:  server.URL,
+	}
+	tools := &toolRegistry{}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if _, err := client.MakeRequest(context.Background(), "/user", "GET", nil); err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+			client.RateLimit()
+			if i%5 == 0 {
+				name := []string{"a", "b"}[i%2]
+				client.switchAccount(name, client.Accounts[name])
+			}
+			key := fmt.Sprintf("key-%d", i)
+			if _, _, err := client.beginCreate(context.Background(), key, time.Now()); err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+			client.finishCreate(key, &idempotentResult{ID: i}, time.Now())
+			tools.registered()
+		}(i)
+	}
+	wg.Wait()
+
+	if rateLimit := client.RateLimit(); !rateLimit.Seen || rateLimit.Remaining != 100 {
+		t.Errorf("Unexpected rate limit: %+v", rateLimit)
+	}
+}
@@ -0,0 +1,948 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	mcp "github.com/metoro-io/mcp-golang"
+)
+
+// unsortedCollectionID is Raindrop's system collection for bookmarks without a collection.
+const unsortedCollectionID = -1
+
+// systemCollectionNames labels Raindrop's built-in collections.
+var systemCollectionNames = map[int]string{
+	0:                    "All bookmarks",
+	unsortedCollectionID: "Unsorted",
+	trashCollectionID:    "Trash",
+}
+
+// fetchCollections returns every root and nested collection keyed by ID.
+func (r *RaindropClient) fetchCollections(ctx context.Context) (map[int]map[string]interface{}, error) {
+	collections := map[int]map[string]interface{}{}
+
+	for _, endpoint := range []string{"/collections", "/collections/childrens"} {
+		result, err := r.MakeRequest(ctx, endpoint, "GET", nil)
+		if err != nil {
+			return nil, err
+		}
+
+		items, ok := result["items"].([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("unable to parse collections")
+		}
+		for _, item := range items {
+			if collection, ok := item.(map[string]interface{}); ok {
+				collections[intField(collection, "_id")] = collection
+			}
+		}
+	}
+
+	return collections, nil
+}
+
+// getCollection fetches a single collection by ID.
+func (r *RaindropClient) getCollection(ctx context.Context, id int) (map[string]interface{}, error) {
+	result, err := r.MakeRequest(ctx, fmt.Sprintf("/collection/%d", id), "GET", nil)
+	if err != nil {
+		return nil, err
+	}
+	item, ok := result["item"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unable to parse collection %d", id)
+	}
+	return item, nil
+}
+
+// collectionTitle returns a display title for a collection, including system collections.
+func (r *RaindropClient) collectionTitle(ctx context.Context, id int) (string, error) {
+	if name, ok := systemCollectionNames[id]; ok {
+		return name, nil
+	}
+	collection, err := r.getCollection(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	return stringField(collection, "title"), nil
+}
+
+// collectionNames maps every collection ID, including system collections, to its title.
+func (r *RaindropClient) collectionNames(ctx context.Context) (map[int]string, error) {
+	collections, err := r.fetchCollections(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	names := map[int]string{}
+	for id, name := range systemCollectionNames {
+		names[id] = name
+	}
+	for id, collection := range collections {
+		names[id] = stringField(collection, "title")
+	}
+	return names, nil
+}
+
+// parentIDOf returns the ID of a collection's parent, or 0 for root collections.
+func parentIDOf(collection map[string]interface{}) int {
+	if parent, ok := collection["parent"].(map[string]interface{}); ok {
+		return intField(parent, "$id")
+	}
+	return 0
+}
+
+// collectionPath returns the titles from the root collection down to id.
+func collectionPath(collections map[int]map[string]interface{}, id int) []string {
+	if name, ok := systemCollectionNames[id]; ok {
+		return []string{name}
+	}
+
+	var path []string
+	seen := map[int]bool{}
+	for id != 0 && !seen[id] {
+		seen[id] = true
+		collection, ok := collections[id]
+		if !ok {
+			path = append([]string{fmt.Sprintf("Collection %d", id)}, path...)
+			break
+		}
+		path = append([]string{stringField(collection, "title")}, path...)
+		id = parentIDOf(collection)
+	}
+	return path
+}
+
+// descendantIDs returns the IDs of every collection nested below id, at any depth.
+func descendantIDs(collections map[int]map[string]interface{}, id int) []int {
+	children := map[int][]int{}
+	for childID, collection := range collections {
+		parentID := parentIDOf(collection)
+		children[parentID] = append(children[parentID], childID)
+	}
+
+	var result []int
+	queue := []int{id}
+	seen := map[int]bool{id: true}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, childID := range children[current] {
+			if seen[childID] {
+				continue
+			}
+			seen[childID] = true
+			result = append(result, childID)
+			queue = append(queue, childID)
+		}
+	}
+	sort.Ints(result)
+	return result
+}
+
+type GetBookmarkLocationArgs struct {
+	ID int `json:"id" jsonschema:"required,description=Bookmark ID"`
+}
+
+func getBookmarkLocationHandler(client *RaindropClient) func(ctx context.Context, args GetBookmarkLocationArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args GetBookmarkLocationArgs) (*mcp.ToolResponse, error) {
+		if args.ID == 0 {
+			return nil, fmt.Errorf("ID is required")
+		}
+
+		bookmark, err := client.getRaindrop(ctx, args.ID)
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+
+		collectionID := collectionIDOf(bookmark)
+		var collections map[int]map[string]interface{}
+		if _, ok := systemCollectionNames[collectionID]; !ok {
+			collections, err = client.fetchCollections(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("internal error: %v", err)
+			}
+		}
+
+		breadcrumb := strings.Join(collectionPath(collections, collectionID), " > ")
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(fmt.Sprintf("%q is in %s (collection %d)", stringField(bookmark, "title"), breadcrumb, collectionID)),
+		), nil
+	}
+}
+
+type SetCollectionCoverFromItemArgs struct {
+	CollectionID int `json:"collectionId" jsonschema:"required,description=Collection ID to update"`
+	BookmarkID   int `json:"bookmarkId" jsonschema:"required,description=ID of a bookmark in that collection whose cover to use"`
+}
+
+func setCollectionCoverFromItemHandler(client *RaindropClient) func(ctx context.Context, args SetCollectionCoverFromItemArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args SetCollectionCoverFromItemArgs) (*mcp.ToolResponse, error) {
+		if args.CollectionID <= 0 {
+			return nil, fmt.Errorf("collection ID must be a user collection")
+		}
+		if args.BookmarkID == 0 {
+			return nil, fmt.Errorf("bookmark ID is required")
+		}
+
+		bookmark, err := client.getRaindrop(ctx, args.BookmarkID)
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+		if collectionIDOf(bookmark) != args.CollectionID {
+			return nil, fmt.Errorf("bookmark %d is not in collection %d", args.BookmarkID, args.CollectionID)
+		}
+
+		cover := stringField(bookmark, "cover")
+		if cover == "" {
+			return nil, fmt.Errorf("bookmark %d has no cover image", args.BookmarkID)
+		}
+
+		result, err := client.MakeRequest(ctx, fmt.Sprintf("/collection/%d", args.CollectionID), "PUT", map[string]interface{}{
+			"cover": []string{cover},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+
+		applied := cover
+		if item, ok := result["item"].(map[string]interface{}); ok {
+			if covers, ok := item["cover"].([]interface{}); ok && len(covers) > 0 {
+				if c, ok := covers[0].(string); ok {
+					applied = c
+				}
+			}
+		}
+
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(fmt.Sprintf("Collection %d cover set to %s", args.CollectionID, applied)),
+		), nil
+	}
+}
+
+// createCollection creates a collection under parentID (0 for a root collection).
+func (r *RaindropClient) createCollection(ctx context.Context, title string, parentID int) (map[string]interface{}, error) {
+	body := map[string]interface{}{"title": title}
+	if parentID != 0 {
+		body["parent"] = map[string]interface{}{"$id": parentID}
+	}
+
+	result, err := r.MakeRequest(ctx, "/collection", "POST", body)
+	if err != nil {
+		return nil, err
+	}
+	item, ok := result["item"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unable to parse created collection %q", title)
+	}
+	return item, nil
+}
+
+// findChildCollection returns the ID of the collection named title directly under
+// parentID (0 for root), matching case-insensitively.
+func findChildCollection(collections map[int]map[string]interface{}, parentID int, title string) (int, bool) {
+	ids := make([]int, 0, len(collections))
+	for id := range collections {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	for _, id := range ids {
+		collection := collections[id]
+		if parentIDOf(collection) == parentID && strings.EqualFold(stringField(collection, "title"), title) {
+			return id, true
+		}
+	}
+	return 0, false
+}
+
+// splitCollectionPath splits "Work/Projects/Go" into its trimmed, non-empty segments.
+func splitCollectionPath(path string) []string {
+	var segments []string
+	for _, segment := range strings.Split(path, "/") {
+		if segment = strings.TrimSpace(segment); segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+	return segments
+}
+
+// collectionStep is one segment of a collection path resolved by ensureCollectionPath.
+type collectionStep struct {
+	Title   string
+	ID      int
+	Created bool
+}
+
+// ensureCollectionPath walks segments from the root, reusing the collection
+// with each title (case-insensitively) and creating the ones missing. Created
+// collections are added to collections.
+func (r *RaindropClient) ensureCollectionPath(ctx context.Context, collections map[int]map[string]interface{}, segments []string) ([]collectionStep, error) {
+	var steps []collectionStep
+	parentID := 0
+	for _, segment := range segments {
+		step := collectionStep{Title: segment}
+		id, ok := findChildCollection(collections, parentID, segment)
+		if !ok {
+			created, err := r.createCollection(ctx, segment, parentID)
+			if err != nil {
+				return nil, fmt.Errorf("internal error: creating %q: %v", segment, err)
+			}
+			id = intField(created, "_id")
+			collections[id] = created
+			step.Created = true
+		}
+		step.ID = id
+		steps = append(steps, step)
+		parentID = id
+	}
+	return steps, nil
+}
+
+type CreateCollectionPathArgs struct {
+	Path string `json:"path" jsonschema:"required,description=Slash-separated collection path such as Work/Projects/Go"`
+}
+
+func createCollectionPathHandler(client *RaindropClient) func(ctx context.Context, args CreateCollectionPathArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args CreateCollectionPathArgs) (*mcp.ToolResponse, error) {
+		segments := splitCollectionPath(args.Path)
+		if len(segments) == 0 {
+			return nil, fmt.Errorf("path is required")
+		}
+
+		collections, err := client.fetchCollections(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+
+		steps, err := client.ensureCollectionPath(ctx, collections, segments)
+		if err != nil {
+			return nil, err
+		}
+
+		var chain strings.Builder
+		for _, step := range steps {
+			status := "existing"
+			if step.Created {
+				status = "created"
+			}
+			chain.WriteString(fmt.Sprintf("\n%s: %d (%s)", step.Title, step.ID, status))
+		}
+
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(fmt.Sprintf("Collection path %s resolved to collection %d:%s", strings.Join(segments, " > "), steps[len(steps)-1].ID, chain.String())),
+		), nil
+	}
+}
+
+type SetCollectionsPublicArgs struct {
+	BulkOptions
+	IDs    []int `json:"ids" jsonschema:"required,description=Collection IDs to update"`
+	Public bool  `json:"public" jsonschema:"description=Whether the collections should be public"`
+	JSON   bool  `json:"json,omitempty" jsonschema:"description=Return the result as JSON"`
+}
+
+func setCollectionsPublicHandler(client *RaindropClient) func(ctx context.Context, args SetCollectionsPublicArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args SetCollectionsPublicArgs) (*mcp.ToolResponse, error) {
+		if len(args.IDs) == 0 {
+			return nil, fmt.Errorf("at least one collection ID is required")
+		}
+
+		ctx, cancel := client.bulkContext(ctx)
+		defer cancel()
+
+		result := client.bulkApply(ctx, args.IDs, args.StopOnError, func(ctx context.Context, id int) error {
+			if id <= 0 {
+				return fmt.Errorf("system collections cannot be shared")
+			}
+			_, err := client.MakeRequest(ctx, fmt.Sprintf("/collection/%d", id), "PUT", map[string]interface{}{"public": args.Public})
+			return err
+		})
+
+		if args.JSON {
+			return jsonResponse(result)
+		}
+
+		state := "private"
+		if args.Public {
+			state = "public"
+		}
+
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(fmt.Sprintf("Made %d of %d collections %s:%s", len(result.Succeeded), len(args.IDs), state, result.Text())),
+		), nil
+	}
+}
+
+// maxDiffListed caps how many URLs diff-collections lists per group.
+const maxDiffListed = 50
+
+type DiffCollectionsArgs struct {
+	A int `json:"a" jsonschema:"required,description=ID of the first collection"`
+	B int `json:"b" jsonschema:"required,description=ID of the second collection"`
+}
+
+// collectionDiff is the result of comparing the URLs of two collections.
+type collectionDiff struct {
+	Both  []string
+	OnlyA []string
+	OnlyB []string
+}
+
+// diffLinks compares two lists of bookmarks by normalized URL. Each group keeps
+// the link as saved in the first bookmark seen, in collection order.
+func diffLinks(a, b []map[string]interface{}) collectionDiff {
+	inB := map[string]bool{}
+	for _, item := range b {
+		inB[normalizeURL(stringField(item, "link"))] = true
+	}
+
+	var diff collectionDiff
+	inA := map[string]bool{}
+	for _, item := range a {
+		link := stringField(item, "link")
+		key := normalizeURL(link)
+		if inA[key] {
+			continue
+		}
+		inA[key] = true
+		if inB[key] {
+			diff.Both = append(diff.Both, link)
+		} else {
+			diff.OnlyA = append(diff.OnlyA, link)
+		}
+	}
+
+	seenB := map[string]bool{}
+	for _, item := range b {
+		link := stringField(item, "link")
+		key := normalizeURL(link)
+		if inA[key] || seenB[key] {
+			continue
+		}
+		seenB[key] = true
+		diff.OnlyB = append(diff.OnlyB, link)
+	}
+	return diff
+}
+
+// writeLinkGroup appends a heading with the group size and up to maxDiffListed links.
+func writeLinkGroup(report *strings.Builder, heading string, links []string) {
+	report.WriteString(fmt.Sprintf("\n\n%s (%d):", heading, len(links)))
+	for i, link := range links {
+		if i == maxDiffListed {
+			report.WriteString(fmt.Sprintf("\n... and %d more", len(links)-maxDiffListed))
+			break
+		}
+		report.WriteString("\n" + link)
+	}
+}
+
+func diffCollectionsHandler(client *RaindropClient) func(ctx context.Context, args DiffCollectionsArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args DiffCollectionsArgs) (*mcp.ToolResponse, error) {
+		if args.A == args.B {
+			return nil, fmt.Errorf("collections A and B must differ")
+		}
+
+		ctx, cancel := client.bulkContext(ctx)
+		defer cancel()
+
+		itemsA, err := client.listRaindrops(ctx, args.A, url.Values{}, maxExportItems)
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+		itemsB, err := client.listRaindrops(ctx, args.B, url.Values{}, maxExportItems)
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+
+		diff := diffLinks(itemsA, itemsB)
+
+		var report strings.Builder
+		report.WriteString(fmt.Sprintf("Compared collection %d (%d bookmarks) with collection %d (%d bookmarks).", args.A, len(itemsA), args.B, len(itemsB)))
+		writeLinkGroup(&report, "In both", diff.Both)
+		writeLinkGroup(&report, fmt.Sprintf("Only in %d", args.A), diff.OnlyA)
+		writeLinkGroup(&report, fmt.Sprintf("Only in %d", args.B), diff.OnlyB)
+
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(report.String()),
+		), nil
+	}
+}
+
+const (
+	// defaultGalleryItems is how many covers collection-gallery returns by default.
+	defaultGalleryItems = 24
+	// maxGalleryItems caps how many covers collection-gallery returns.
+	maxGalleryItems = 100
+)
+
+type CollectionGalleryArgs struct {
+	ID    int `json:"id" jsonschema:"required,description=Collection ID"`
+	Limit int `json:"limit,omitempty" jsonschema:"description=Maximum number of covers to return (default 24, cap 100)"`
+}
+
+// galleryEntry is one thumbnail returned by collection-gallery.
+type galleryEntry struct {
+	ID    int    `json:"id"`
+	Title string `json:"title"`
+	Cover string `json:"cover"`
+}
+
+// galleryEntries returns up to limit entries for the items that have a cover.
+func galleryEntries(items []map[string]interface{}, limit int) []galleryEntry {
+	entries := []galleryEntry{}
+	for _, item := range items {
+		if len(entries) >= limit {
+			break
+		}
+		cover := stringField(item, "cover")
+		if cover == "" {
+			continue
+		}
+		entries = append(entries, galleryEntry{ID: intField(item, "_id"), Title: stringField(item, "title"), Cover: cover})
+	}
+	return entries
+}
+
+func collectionGalleryHandler(client *RaindropClient) func(ctx context.Context, args CollectionGalleryArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args CollectionGalleryArgs) (*mcp.ToolResponse, error) {
+		limit := args.Limit
+		if limit <= 0 {
+			limit = defaultGalleryItems
+		}
+		if limit > maxGalleryItems {
+			limit = maxGalleryItems
+		}
+
+		ctx, cancel := client.bulkContext(ctx)
+		defer cancel()
+
+		// Items without covers are skipped, so page on until limit covers
+		// are found rather than fetching only limit items.
+		entries := []galleryEntry{}
+		err := client.eachRaindropPage(ctx, args.ID, url.Values{}, maxExportItems, func(page []map[string]interface{}) error {
+			entries = append(entries, galleryEntries(page, limit-len(entries))...)
+			if len(entries) >= limit {
+				return errStopPaging
+			}
+			return nil
+		})
+		if err != nil && !errors.Is(err, errStopPaging) {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+
+		return jsonResponse(entries)
+	}
+}
+
+type ConsolidateCollectionArgs struct {
+	SourceID int  `json:"sourceId" jsonschema:"required,description=ID of the collection to empty and delete"`
+	TargetID int  `json:"targetId" jsonschema:"required,description=ID of the collection receiving the bookmarks"`
+	JSON     bool `json:"json,omitempty" jsonschema:"description=Return the result as JSON"`
+}
+
+func consolidateCollectionHandler(client *RaindropClient) func(ctx context.Context, args ConsolidateCollectionArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args ConsolidateCollectionArgs) (*mcp.ToolResponse, error) {
+		if args.SourceID <= 0 || args.TargetID <= 0 {
+			return nil, fmt.Errorf("source and target must be user collections")
+		}
+		if args.SourceID == args.TargetID {
+			return nil, fmt.Errorf("source and target must differ")
+		}
+
+		ctx, cancel := client.bulkContext(ctx)
+		defer cancel()
+
+		collections, err := client.fetchCollections(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+		for _, id := range []int{args.SourceID, args.TargetID} {
+			if _, ok := collections[id]; !ok {
+				return nil, fmt.Errorf("collection %d not found", id)
+			}
+		}
+		// Deleting a collection also deletes its sub-collections, so refuse
+		// rather than take nested bookmarks to the trash with it.
+		if children := descendantIDs(collections, args.SourceID); len(children) > 0 {
+			return nil, fmt.Errorf("collection %d has %d sub-collections; move or merge them first", args.SourceID, len(children))
+		}
+
+		// Move the bookmarks by ID, a listing at a time, so every bookmark gets
+		// its own outcome. Each ID is sent once: the loop ends when a listing
+		// has nothing new, still shows IDs already sent, or a round moves
+		// nothing, leaving the bookmarks it could not move in the source.
+		source := stringField(collections[args.SourceID], "title")
+		target := map[string]interface{}{"collection": map[string]interface{}{"$id": args.TargetID}}
+		result := newBulkResult(nil, nil)
+		attempted := map[int]bool{}
+		var sent []int
+		for {
+			items, err := client.listRaindrops(ctx, args.SourceID, url.Values{}, maxExportItems)
+			if err != nil {
+				return nil, fmt.Errorf("internal error: %v", err)
+			}
+			var ids []int
+			stuck := false
+			for _, item := range items {
+				id := intField(item, "_id")
+				if attempted[id] {
+					stuck = true
+					continue
+				}
+				attempted[id] = true
+				ids = append(ids, id)
+			}
+			if len(ids) == 0 {
+				break
+			}
+			sent = append(sent, ids...)
+
+			moved, modified := client.updateRaindropsBulk(ctx, args.SourceID, ids, target, false)
+			result.Failed = append(result.Failed, moved.Failed...)
+			if stuck || len(moved.Failed) > 0 || modified == 0 {
+				break
+			}
+		}
+
+		// A bookmark counts as moved once it is no longer in the source.
+		rest, err := client.listRaindrops(ctx, args.SourceID, url.Values{}, maxExportItems)
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+		left := map[int]bool{}
+		for _, item := range rest {
+			left[intField(item, "_id")] = true
+		}
+		failed := map[int]bool{}
+		for _, failure := range result.Failed {
+			failed[failure.ID] = true
+		}
+		for _, id := range sent {
+			switch {
+			case failed[id]:
+				// Already reported with the error of its batch.
+			case left[id]:
+				result.Failed = append(result.Failed, BulkFailure{ID: id, Err: fmt.Sprintf("still in collection %d after the move", args.SourceID)})
+			default:
+				result.Succeeded = append(result.Succeeded, id)
+			}
+		}
+
+		deleted := false
+		var deleteErr error
+		if len(result.Failed) == 0 {
+			remaining, err := client.CountMatches(ctx, args.SourceID, nil)
+			switch {
+			case err != nil:
+				deleteErr = err
+			case remaining > 0:
+				deleteErr = fmt.Errorf("%d bookmarks remain in it", remaining)
+			default:
+				_, deleteErr = client.MakeRequest(ctx, fmt.Sprintf("/collection/%d", args.SourceID), "DELETE", nil)
+				deleted = deleteErr == nil
+			}
+		}
+
+		if args.JSON {
+			output := map[string]interface{}{"deleted": deleted, "result": result}
+			if deleteErr != nil {
+				output["deleteError"] = deleteErr.Error()
+			}
+			return jsonResponse(output)
+		}
+
+		responseText := fmt.Sprintf("Moved %d bookmarks from %q to %q", len(result.Succeeded), source, stringField(collections[args.TargetID], "title"))
+		switch {
+		case deleted:
+			responseText += fmt.Sprintf(" and deleted %q.", source)
+		case deleteErr != nil:
+			responseText += fmt.Sprintf("; %q was not deleted: %v", source, deleteErr)
+		default:
+			responseText += fmt.Sprintf("; %q was not deleted because some bookmarks could not be moved.", source)
+		}
+		responseText += result.FailedText()
+
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(responseText),
+		), nil
+	}
+}
+
+// itemSorts are the item orders Raindrop supports, with a description of each.
+var itemSorts = map[string]string{
+	"-created": "newest first",
+	"created":  "oldest first",
+	"title":    "title A-Z",
+	"-title":   "title Z-A",
+	"domain":   "domain A-Z",
+	"-domain":  "domain Z-A",
+	"-sort":    "manual order",
+}
+
+type SetCollectionItemSortArgs struct {
+	ID   int    `json:"id" jsonschema:"required,description=Collection ID"`
+	Sort string `json:"sort" jsonschema:"required,enum=-created,enum=created,enum=title,enum=-title,enum=domain,enum=-domain,enum=-sort,description=Default order of the collection's bookmarks"`
+}
+
+func setCollectionItemSortHandler(client *RaindropClient) func(ctx context.Context, args SetCollectionItemSortArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args SetCollectionItemSortArgs) (*mcp.ToolResponse, error) {
+		if args.ID <= 0 {
+			return nil, fmt.Errorf("collection ID must be a user collection")
+		}
+		description, ok := itemSorts[args.Sort]
+		if !ok {
+			return nil, fmt.Errorf("invalid sort %q: must be one of -created, created, title, -title, domain, -domain, -sort", args.Sort)
+		}
+
+		result, err := client.MakeRequest(ctx, fmt.Sprintf("/collection/%d", args.ID), "PUT", map[string]interface{}{"sort": args.Sort})
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+
+		applied := args.Sort
+		if item, ok := result["item"].(map[string]interface{}); ok {
+			if sort := stringField(item, "sort"); sort != "" {
+				applied = sort
+			}
+		}
+		if d, ok := itemSorts[applied]; ok {
+			description = d
+		}
+
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(fmt.Sprintf("Collection %d now sorts its bookmarks by %s (%s).", args.ID, applied, description)),
+		), nil
+	}
+}
+
+// splitPlan assigns each bookmark carrying one of tags to the first such tag,
+// in the order tags are given. Bookmarks with none of the tags are left out.
+func splitPlan(items []map[string]interface{}, tags []string) map[string][]int {
+	plan := map[string][]int{}
+	for _, item := range items {
+		for _, tag := range tags {
+			if hasTag(item, tag) {
+				plan[tag] = append(plan[tag], intField(item, "_id"))
+				break
+			}
+		}
+	}
+	return plan
+}
+
+type SplitByTagArgs struct {
+	BulkOptions
+	SourceCollection int      `json:"sourceCollection" jsonschema:"required,description=ID of the collection to split"`
+	Tags             []string `json:"tags" jsonschema:"required,description=Tags to split out; each gets a sub-collection named after it"`
+	DryRun           bool     `json:"dryRun,omitempty" jsonschema:"description=Report the planned moves without creating collections or moving bookmarks"`
+	JSON             bool     `json:"json,omitempty" jsonschema:"description=Return the result as JSON"`
+}
+
+func splitByTagHandler(client *RaindropClient) func(ctx context.Context, args SplitByTagArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args SplitByTagArgs) (*mcp.ToolResponse, error) {
+		if args.SourceCollection <= 0 {
+			return nil, fmt.Errorf("sourceCollection must be a collection ID; system collections cannot have sub-collections")
+		}
+		tags := mergeTags(args.Tags)
+		if len(tags) == 0 {
+			return nil, fmt.Errorf("at least one tag is required")
+		}
+
+		ctx, cancel := client.bulkContext(ctx)
+		defer cancel()
+
+		collections, err := client.fetchCollections(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+		if _, ok := collections[args.SourceCollection]; !ok {
+			return nil, fmt.Errorf("collection %d not found", args.SourceCollection)
+		}
+
+		items, err := client.listRaindrops(ctx, args.SourceCollection, url.Values{}, maxExportItems)
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+		plan := splitPlan(items, tags)
+
+		var report strings.Builder
+		result := newBulkResult(nil, nil)
+		targets := map[string]int{}
+		for i, tag := range tags {
+			if len(result.Failed) > 0 && args.StopOnError {
+				for _, rest := range tags[i:] {
+					result.Skipped = append(result.Skipped, plan[rest]...)
+				}
+				report.WriteString(fmt.Sprintf("\nStopped after the failure; skipped %s", strings.Join(tags[i:], ", ")))
+				break
+			}
+			ids := plan[tag]
+			id, exists := findChildCollection(collections, args.SourceCollection, tag)
+			status := "existing"
+			if !exists {
+				status = "new"
+			}
+
+			if args.DryRun {
+				target := "a new sub-collection"
+				if exists {
+					target = fmt.Sprintf("collection %d", id)
+					targets[tag] = id
+				}
+				report.WriteString(fmt.Sprintf("\n%s: would move %d bookmarks to %s", tag, len(ids), target))
+				result.Succeeded = append(result.Succeeded, ids...)
+				continue
+			}
+			if len(ids) == 0 {
+				report.WriteString(fmt.Sprintf("\n%s: no bookmarks", tag))
+				continue
+			}
+
+			if !exists {
+				created, err := client.createCollection(ctx, tag, args.SourceCollection)
+				if err != nil {
+					report.WriteString(fmt.Sprintf("\n%s: failed to create collection (%v)", tag, err))
+					for _, id := range ids {
+						result.Failed = append(result.Failed, BulkFailure{ID: id, Err: fmt.Sprintf("failed to create collection %q: %v", tag, err)})
+					}
+					continue
+				}
+				id = intField(created, "_id")
+				collections[id] = created
+				status = "created"
+			}
+			targets[tag] = id
+
+			moved, _ := client.updateRaindropsBulk(ctx, args.SourceCollection, ids, map[string]interface{}{"collection": map[string]interface{}{"$id": id}}, args.StopOnError)
+			result.merge(moved)
+			if len(moved.Failed) > 0 {
+				report.WriteString(fmt.Sprintf("\n%s: moved %d of %d bookmarks to collection %d (%s), then failed (%s)", tag, len(moved.Succeeded), len(ids), id, status, moved.Failed[0].Err))
+				continue
+			}
+			report.WriteString(fmt.Sprintf("\n%s: moved %d bookmarks to collection %d (%s)", tag, len(moved.Succeeded), id, status))
+		}
+
+		if args.JSON {
+			return jsonResponse(map[string]interface{}{
+				"dryRun":      args.DryRun,
+				"collections": targets,
+				"result":      result,
+			})
+		}
+
+		verb := "Moved"
+		if args.DryRun {
+			verb = "Would move"
+		}
+		report.WriteString(result.FailedText())
+
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(fmt.Sprintf("%s %d of %d bookmarks out of collection %d; the rest stay in place:%s",
+				verb, len(result.Succeeded), len(items), args.SourceCollection, report.String())),
+		), nil
+	}
+}
+
+// matchCollectionName returns the IDs of the collections, at any depth, whose
+// title best matches name. Titles equal to name ignoring case and punctuation
+// win; otherwise the titles within a small edit distance (one edit per four
+// characters of name) that are closest to it. The IDs are sorted, and more than
+// one means the name is ambiguous.
+func matchCollectionName(collections map[int]map[string]interface{}, name string) []int {
+	target := normalizeTitle(name)
+	if target == "" {
+		return nil
+	}
+	within := len([]rune(target)) / 4
+
+	var matches []int
+	best := -1
+	for id, collection := range collections {
+		distance := levenshteinDistance(normalizeTitle(stringField(collection, "title")), target)
+		switch {
+		case distance > within:
+			continue
+		case best < 0 || distance < best:
+			matches, best = []int{id}, distance
+		case distance == best:
+			matches = append(matches, id)
+		}
+	}
+	sort.Ints(matches)
+	return matches
+}
+
+type SaveToNamedCollectionArgs struct {
+	URL            string   `json:"url" jsonschema:"required,description=URL to bookmark"`
+	CollectionName string   `json:"collectionName" jsonschema:"required,description=Name of the collection to save into, matched loosely and created at the root if missing; a path such as Work/Go is matched exactly and created as needed"`
+	Tags           []string `json:"tags,omitempty" jsonschema:"description=Tags for the bookmark"`
+}
+
+func saveToNamedCollectionHandler(client *RaindropClient) func(ctx context.Context, args SaveToNamedCollectionArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args SaveToNamedCollectionArgs) (*mcp.ToolResponse, error) {
+		if err := validateURL(args.URL); err != nil {
+			return nil, err
+		}
+		segments := splitCollectionPath(args.CollectionName)
+		if len(segments) == 0 {
+			return nil, fmt.Errorf("collection name is required")
+		}
+
+		collections, err := client.fetchCollections(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+
+		// A single name is matched loosely anywhere in the tree; a path is
+		// walked from the root exactly as create-collection-path does.
+		var matches []int
+		if len(segments) == 1 {
+			matches = matchCollectionName(collections, segments[0])
+		}
+
+		var collectionID int
+		var status string
+		switch {
+		case len(matches) > 1:
+			var candidates []string
+			for _, id := range matches {
+				candidates = append(candidates, fmt.Sprintf("%s (ID %d)", strings.Join(collectionPath(collections, id), "/"), id))
+			}
+			return nil, fmt.Errorf("collection name %q is ambiguous; pass the full path or save with create-bookmark to one of: %s", args.CollectionName, strings.Join(candidates, ", "))
+		case len(matches) == 1:
+			collectionID = matches[0]
+			status = fmt.Sprintf("existing collection %q", stringField(collections[collectionID], "title"))
+		default:
+			steps, err := client.ensureCollectionPath(ctx, collections, segments)
+			if err != nil {
+				return nil, err
+			}
+			last := steps[len(steps)-1]
+			collectionID = last.ID
+			status = fmt.Sprintf("existing collection %q", strings.Join(segments, "/"))
+			if last.Created {
+				status = fmt.Sprintf("new collection %q", strings.Join(segments, "/"))
+			}
+		}
+
+		bookmark, err := client.MakeRequest(ctx, "/raindrop", "POST", map[string]interface{}{
+			"link":        args.URL,
+			"tags":        mergeTags(args.Tags, client.DefaultTags),
+			"collection":  map[string]interface{}{"$id": collectionID},
+			"pleaseParse": map[string]interface{}{},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+		item, _ := bookmark["item"].(map[string]interface{})
+
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(fmt.Sprintf("Bookmark %d saved to %s (ID %d): %s", intField(item, "_id"), status, collectionID, args.URL)),
+		), nil
+	}
+}
@@ -0,0 +1,318 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestCollectionPath(t *testing.T) {
+	collections := map[int]map[string]interface{}{
+		1: {"_id": float64(1), "title": "Programming"},
+		2: {"_id": float64(2), "title": "Go", "parent": map[string]interface{}{"$id": float64(1)}},
+		3: {"_id": float64(3), "title": "Orphan", "parent": map[string]interface{}{"$id": float64(99)}},
+	}
+
+	tests := map[int][]string{
+		2:                    {"Programming", "Go"},
+		1:                    {"Programming"},
+		3:                    {"Collection 99", "Orphan"},
+		unsortedCollectionID: {"Unsorted"},
+		trashCollectionID:    {"Trash"},
+	}
+	for id, expected := range tests {
+		if got := collectionPath(collections, id); !reflect.DeepEqual(got, expected) {
+			t.Errorf("collectionPath(%d) = %v, expected %v", id, got, expected)
+		}
+	}
+}
+
+func TestDescendantIDs(t *testing.T) {
+	collections := map[int]map[string]interface{}{
+		1: {"_id": float64(1)},
+		2: {"_id": float64(2), "parent": map[string]interface{}{"$id": float64(1)}},
+		3: {"_id": float64(3), "parent": map[string]interface{}{"$id": float64(2)}},
+		4: {"_id": float64(4), "parent": map[string]interface{}{"$id": float64(1)}},
+		5: {"_id": float64(5)},
+	}
+
+	if got := descendantIDs(collections, 1); !reflect.DeepEqual(got, []int{2, 3, 4}) {
+		t.Errorf("Expected [2 3 4], got %v", got)
+	}
+	if got := descendantIDs(collections, 5); len(got) != 0 {
+		t.Errorf("Expected no descendants, got %v", got)
+	}
+}
+
+func TestFindChildCollection(t *testing.T) {
+	collections := map[int]map[string]interface{}{
+		1: {"_id": float64(1), "title": "Work"},
+		2: {"_id": float64(2), "title": "Projects", "parent": map[string]interface{}{"$id": float64(1)}},
+		3: {"_id": float64(3), "title": "Projects"},
+	}
+
+	if id, ok := findChildCollection(collections, 1, "projects"); !ok || id != 2 {
+		t.Errorf("Expected nested Projects (2), got %d, %v", id, ok)
+	}
+	if id, ok := findChildCollection(collections, 0, "Projects"); !ok || id != 3 {
+		t.Errorf("Expected root Projects (3), got %d, %v", id, ok)
+	}
+	if _, ok := findChildCollection(collections, 2, "Go"); ok {
+		t.Error("Expected Go to be missing")
+	}
+}
+
+func TestSplitCollectionPath(t *testing.T) {
+	got := splitCollectionPath(" Work / Projects//Go/ ")
+	expected := []string{"Work", "Projects", "Go"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+}
+
+func TestDiffLinks(t *testing.T) {
+	a := []map[string]interface{}{
+		{"link": "https://example.com/read/"},
+		{"link": "https://example.com/only-a"},
+		{"link": "https://EXAMPLE.com/only-a#top"},
+	}
+	b := []map[string]interface{}{
+		{"link": "https://example.com/read"},
+		{"link": "https://example.com/only-b"},
+	}
+
+	diff := diffLinks(a, b)
+	expected := collectionDiff{
+		Both:  []string{"https://example.com/read/"},
+		OnlyA: []string{"https://example.com/only-a"},
+		OnlyB: []string{"https://example.com/only-b"},
+	}
+	if !reflect.DeepEqual(diff, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, diff)
+	}
+}
+
+func TestGalleryEntries(t *testing.T) {
+	items := []map[string]interface{}{
+		{"_id": float64(1), "title": "One", "cover": "https://img.example/1.png"},
+		{"_id": float64(2), "title": "No cover"},
+		{"_id": float64(3), "title": "Three", "cover": "https://img.example/3.png"},
+		{"_id": float64(4), "title": "Four", "cover": "https://img.example/4.png"},
+	}
+
+	got := galleryEntries(items, 2)
+	expected := []galleryEntry{
+		{ID: 1, Title: "One", Cover: "https://img.example/1.png"},
+		{ID: 3, Title: "Three", Cover: "https://img.example/3.png"},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+
+	if got := galleryEntries(nil, 5); got == nil || len(got) != 0 {
+		t.Errorf("Expected empty non-nil slice, got %v", got)
+	}
+}
+
+func TestSplitPlan(t *testing.T) {
+	items := []map[string]interface{}{
+		{"_id": float64(1), "tags": []interface{}{"go", "web"}},
+		{"_id": float64(2), "tags": []interface{}{"Web"}},
+		{"_id": float64(3), "tags": []interface{}{"misc"}},
+		{"_id": float64(4)},
+		{"_id": float64(5), "tags": []interface{}{"web", "go"}},
+	}
+
+	plan := splitPlan(items, []string{"go", "web"})
+	expected := map[string][]int{"go": {1, 5}, "web": {2}}
+	if !reflect.DeepEqual(plan, expected) {
+		t.Errorf("Expected %v, got %v", expected, plan)
+	}
+}
+
+func TestMatchCollectionName(t *testing.T) {
+	collections := map[int]map[string]interface{}{
+		1: {"title": "Reading List"},
+		2: {"title": "Recipes"},
+		3: {"title": "Go", "parent": map[string]interface{}{"$id": float64(10)}},
+		4: {"title": "Go", "parent": map[string]interface{}{"$id": float64(11)}},
+		5: {"title": "Recipe"},
+	}
+
+	tests := map[string][]int{
+		"reading-list": {1},
+		"Readng List":  {1},
+		"recipes":      {2},
+		"Recipe":       {5},
+		"go":           {3, 4},
+		"Travel":       nil,
+		"Goo":          nil,
+		"":             nil,
+	}
+	for name, expected := range tests {
+		if got := matchCollectionName(collections, name); !reflect.DeepEqual(got, expected) {
+			t.Errorf("matchCollectionName(%q) = %v, expected %v", name, got, expected)
+		}
+	}
+}
+
+func TestConsolidateCollectionKeepsSourceOnFailedMove(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/collections":
+			w.Write([]byte(`{"items": [{"_id": 1, "title": "Old"}, {"_id": 2, "title": "New"}]}`))
+		case r.URL.Path == "/collections/childrens":
+			w.Write([]byte(`{"items": []}`))
+		case r.Method == "GET" && r.URL.Path == "/raindrops/1":
+			w.Write([]byte(`{"items": [{"_id": 10}, {"_id": 11}]}`))
+		case r.Method == "PUT" && r.URL.Path == "/raindrops/1":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := &RaindropClient{Token: "test-token", BaseURL: server.URL}
+	resp, err := consolidateCollectionHandler(client)(context.Background(), ConsolidateCollectionArgs{SourceID: 1, TargetID: 2, JSON: true})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var output struct {
+		Deleted bool       `json:"deleted"`
+		Result  BulkResult `json:"result"`
+	}
+	if err := json.Unmarshal([]byte(resp.Content[0].TextContent.Text), &output); err != nil {
+		t.Fatalf("Unexpected JSON: %v", err)
+	}
+	if output.Deleted || len(output.Result.Succeeded) != 0 || len(output.Result.Failed) != 2 || output.Result.Failed[0].ID != 10 {
+		t.Errorf("Expected both bookmarks to fail and the source to stay, got %+v", output)
+	}
+}
+
+func TestCollectionGalleryStopsPaging(t *testing.T) {
+	pages := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pages++
+		// Every tenth bookmark has a cover.
+		items := make([]map[string]interface{}, raindropPageSize)
+		for i := range items {
+			items[i] = map[string]interface{}{"_id": pages*raindropPageSize + i}
+			if i%10 == 0 {
+				items[i]["cover"] = "https://img.example/cover.png"
+			}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"items": items})
+	}))
+	defer server.Close()
+
+	covers := raindropPageSize/10 + 1
+	client := &RaindropClient{Token: "test-token", BaseURL: server.URL}
+	resp, err := collectionGalleryHandler(client)(context.Background(), CollectionGalleryArgs{ID: 1, Limit: covers})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var entries []galleryEntry
+	if err := json.Unmarshal([]byte(resp.Content[0].TextContent.Text), &entries); err != nil {
+		t.Fatalf("Unexpected JSON: %v", err)
+	}
+	if len(entries) != covers {
+		t.Errorf("Expected %d covers, got %d", covers, len(entries))
+	}
+	if pages != 2 {
+		t.Errorf("Expected paging to stop after the second page, got %d requests", pages)
+	}
+}
+
+func TestConsolidateCollectionStopsWhenNothingMoves(t *testing.T) {
+	puts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/collections":
+			w.Write([]byte(`{"items": [{"_id": 1, "title": "Old"}, {"_id": 2, "title": "New"}]}`))
+		case r.URL.Path == "/collections/childrens":
+			w.Write([]byte(`{"items": []}`))
+		case r.Method == "GET" && r.URL.Path == "/raindrops/1":
+			w.Write([]byte(`{"items": [{"_id": 10}, {"_id": 11}]}`))
+		case r.Method == "PUT" && r.URL.Path == "/raindrops/1":
+			// Accepted, but nothing is moved.
+			puts++
+			w.Write([]byte(`{"result": true, "modified": 0}`))
+		default:
+			t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := &RaindropClient{Token: "test-token", BaseURL: server.URL}
+	resp, err := consolidateCollectionHandler(client)(context.Background(), ConsolidateCollectionArgs{SourceID: 1, TargetID: 2, JSON: true})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var output struct {
+		Deleted bool       `json:"deleted"`
+		Result  BulkResult `json:"result"`
+	}
+	if err := json.Unmarshal([]byte(resp.Content[0].TextContent.Text), &output); err != nil {
+		t.Fatalf("Unexpected JSON: %v", err)
+	}
+	if puts != 1 {
+		t.Errorf("Expected one move request, got %d", puts)
+	}
+	if output.Deleted || len(output.Result.Succeeded) != 0 || len(output.Result.Failed) != 2 {
+		t.Errorf("Expected both bookmarks to fail once and the source to stay, got %+v", output)
+	}
+}
+
+func TestConsolidateCollectionCountsEachMoveOnce(t *testing.T) {
+	puts, lists := 0, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/collections":
+			w.Write([]byte(`{"items": [{"_id": 1, "title": "Old"}, {"_id": 2, "title": "New"}]}`))
+		case r.URL.Path == "/collections/childrens":
+			w.Write([]byte(`{"items": []}`))
+		case r.Method == "GET" && r.URL.Path == "/raindrops/1" && r.URL.Query().Get("page") == "0":
+			// Raindrop reports a move but bookmark 11 stays put.
+			lists++
+			if lists == 1 {
+				w.Write([]byte(`{"items": [{"_id": 10}, {"_id": 11}]}`))
+			} else {
+				w.Write([]byte(`{"items": [{"_id": 11}]}`))
+			}
+		case r.Method == "GET" && r.URL.Path == "/raindrops/1":
+			w.Write([]byte(`{"items": []}`))
+		case r.Method == "PUT" && r.URL.Path == "/raindrops/1":
+			puts++
+			w.Write([]byte(`{"result": true, "modified": 2}`))
+		default:
+			t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := &RaindropClient{Token: "test-token", BaseURL: server.URL}
+	resp, err := consolidateCollectionHandler(client)(context.Background(), ConsolidateCollectionArgs{SourceID: 1, TargetID: 2, JSON: true})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var output struct {
+		Deleted bool       `json:"deleted"`
+		Result  BulkResult `json:"result"`
+	}
+	if err := json.Unmarshal([]byte(resp.Content[0].TextContent.Text), &output); err != nil {
+		t.Fatalf("Unexpected JSON: %v", err)
+	}
+	if puts != 1 {
+		t.Errorf("Expected bookmark 11 not to be sent again, got %d move requests", puts)
+	}
+	if !reflect.DeepEqual(output.Result.Succeeded, []int{10}) || len(output.Result.Failed) != 1 || output.Result.Failed[0].ID != 11 || output.Deleted {
+		t.Errorf("Expected 10 moved and 11 left behind, got %+v", output)
+	}
+}
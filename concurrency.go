@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"sync"
+)
+
+// forEachConcurrent calls fn for every index in [0, count), running at most
+// r.Concurrency calls at a time. It returns one error slot per index; indexes
+// not started because ctx was cancelled report ctx.Err().
+func (r *RaindropClient) forEachConcurrent(ctx context.Context, count int, fn func(ctx context.Context, i int) error) []error {
+	limit := r.Concurrency
+	if limit <= 0 {
+		limit = DefaultConcurrency
+	}
+
+	errs := make([]error, count)
+	sem := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+
+	for i := 0; i < count; i++ {
+		if ctx.Err() == nil {
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+			}
+		}
+		if err := ctx.Err(); err != nil {
+			for j := i; j < count; j++ {
+				errs[j] = err
+			}
+			break
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			errs[i] = fn(ctx, i)
+		}(i)
+	}
+
+	wg.Wait()
+	return errs
+}
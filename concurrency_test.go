@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestForEachConcurrent(t *testing.T) {
+	client := &RaindropClient{Concurrency: 2}
+
+	var running, peak int32
+	errs := client.forEachConcurrent(context.Background(), 6, func(ctx context.Context, i int) error {
+		current := atomic.AddInt32(&running, 1)
+		for {
+			old := atomic.LoadInt32(&peak)
+			if current <= old || atomic.CompareAndSwapInt32(&peak, old, current) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+
+		if i == 3 {
+			return errors.New("failed")
+		}
+		return nil
+	})
+
+	if peak > 2 {
+		t.Errorf("Expected at most 2 concurrent calls, got %d", peak)
+	}
+	for i, err := range errs {
+		if (i == 3) != (err != nil) {
+			t.Errorf("Unexpected error at index %d: %v", i, err)
+		}
+	}
+}
+
+func TestForEachConcurrentCancelled(t *testing.T) {
+	client := &RaindropClient{Concurrency: 1}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var calls int32
+	errs := client.forEachConcurrent(ctx, 3, func(ctx context.Context, i int) error {
+		atomic.AddInt32(&calls, 1)
+		return nil
+	})
+
+	if calls != 0 {
+		t.Errorf("Expected no calls after cancellation, got %d", calls)
+	}
+	for i, err := range errs {
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected context.Canceled at index %d, got %v", i, err)
+		}
+	}
+}
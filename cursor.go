@@ -0,0 +1,178 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	mcp "github.com/metoro-io/mcp-golang"
+)
+
+// searchPageSize is how many results search-bookmarks and search-continue return per page.
+const searchPageSize = 25
+
+// searchCursor is the state encoded in a nextCursor token: everything needed to
+// fetch and present the following page of a search the way the first was.
+type searchCursor struct {
+	Collection     int    `json:"collection"`
+	Page           int    `json:"page"`
+	PerPage        int    `json:"perpage"`
+	Search         string `json:"search"`
+	Nested         bool   `json:"nested,omitempty"`
+	ClientSort     string `json:"clientSort,omitempty"`
+	ShowCollection bool   `json:"showCollection,omitempty"`
+}
+
+// encodeCursor turns a cursor into an opaque base64 JSON token.
+func encodeCursor(cursor searchCursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor parses and validates a token produced by encodeCursor.
+func decodeCursor(token string) (searchCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return searchCursor{}, fmt.Errorf("invalid cursor: not a cursor token")
+	}
+
+	var cursor searchCursor
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&cursor); err != nil {
+		return searchCursor{}, fmt.Errorf("invalid cursor: %v", err)
+	}
+	if cursor.Page < 0 || cursor.PerPage <= 0 || cursor.PerPage > raindropPageSize {
+		return searchCursor{}, fmt.Errorf("invalid cursor: page %d of size %d is out of range", cursor.Page, cursor.PerPage)
+	}
+	if cursor.ClientSort != "" {
+		if err := validateClientSort(cursor.ClientSort); err != nil {
+			return searchCursor{}, fmt.Errorf("invalid cursor: %v", err)
+		}
+	}
+	return cursor, nil
+}
+
+// params returns the query parameters for the page the cursor points at.
+func (c searchCursor) params() url.Values {
+	params := url.Values{}
+	params.Set("search", c.Search)
+	params.Set("page", strconv.Itoa(c.Page))
+	params.Set("perpage", strconv.Itoa(c.PerPage))
+	if c.Nested {
+		// Raindrop expands the collection to its whole subtree server-side, so
+		// paging and de-duplication work as for a single collection.
+		params.Set("nested", "true")
+	}
+	return params
+}
+
+// nextCursorNote returns the line pointing at the page after cursor, or "" when
+// the returned page was not full and so was the last one.
+func nextCursorNote(cursor searchCursor, returned int) string {
+	if returned < cursor.PerPage {
+		return ""
+	}
+	cursor.Page++
+	return fmt.Sprintf("\n\nMore results may follow. Pass nextCursor to search-continue for the next page.\nnextCursor: %s", encodeCursor(cursor))
+}
+
+// searchPage fetches the page of a search that cursor points at. returned is
+// the number of items Raindrop sent, for nextCursorNote, which can exceed
+// len(items) when some of them are malformed.
+func (r *RaindropClient) searchPage(ctx context.Context, cursor searchCursor) (items []map[string]interface{}, returned int, err error) {
+	endpoint := fmt.Sprintf("/raindrops/%d?%s", cursor.Collection, cursor.params().Encode())
+	results, err := r.MakeRequest(ctx, endpoint, "GET", nil)
+	if err != nil {
+		return nil, 0, fmt.Errorf("internal error: %v", err)
+	}
+
+	rawItems, ok := results["items"].([]interface{})
+	if !ok {
+		return nil, 0, fmt.Errorf("unable to parse results")
+	}
+	for _, item := range rawItems {
+		if bookmark, ok := item.(map[string]interface{}); ok {
+			items = append(items, bookmark)
+		}
+	}
+	return items, len(rawItems), nil
+}
+
+// formatSearchPage sorts a page of search results with the cursor's client sort
+// and renders it in the search-bookmarks layout, adding each bookmark's
+// collection name when the cursor asks for it.
+func (r *RaindropClient) formatSearchPage(ctx context.Context, cursor searchCursor, items []map[string]interface{}) (string, error) {
+	if cursor.ClientSort != "" {
+		sortBookmarks(items, cursor.ClientSort)
+	}
+
+	// Resolve collection names once per call rather than per item
+	var collectionNames map[int]string
+	if cursor.ShowCollection && len(items) > 0 {
+		var err error
+		collectionNames, err = r.collectionNames(ctx)
+		if err != nil {
+			return "", fmt.Errorf("internal error: %v", err)
+		}
+	}
+
+	var formatted strings.Builder
+	for _, bookmark := range items {
+		tagsStr := "No tags"
+		if tags := extractTags(bookmark); len(tags) > 0 {
+			tagsStr = strings.Join(tags, ", ")
+		}
+
+		formatted.WriteString(fmt.Sprintf("\nTitle: %s\nURL: %s\nTags: %s", stringField(bookmark, "title"), stringField(bookmark, "link"), tagsStr))
+		if cursor.ShowCollection {
+			collectionID := collectionIDOf(bookmark)
+			name, ok := collectionNames[collectionID]
+			if !ok {
+				name = fmt.Sprintf("Collection %d", collectionID)
+			}
+			formatted.WriteString("\nCollection: " + name)
+		}
+		formatted.WriteString("\n---")
+	}
+	return formatted.String(), nil
+}
+
+type SearchContinueArgs struct {
+	Cursor string `json:"cursor" jsonschema:"required,description=nextCursor token from search-bookmarks or search-continue"`
+}
+
+func searchContinueHandler(client *RaindropClient) func(ctx context.Context, args SearchContinueArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args SearchContinueArgs) (*mcp.ToolResponse, error) {
+		cursor, err := decodeCursor(args.Cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		items, returned, err := client.searchPage(ctx, cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(items) == 0 {
+			return mcp.NewToolResponse(
+				mcp.NewTextContent("No more bookmarks match this search."),
+			), nil
+		}
+
+		formatted, err := client.formatSearchPage(ctx, cursor, items)
+		if err != nil {
+			return nil, err
+		}
+
+		responseText := fmt.Sprintf("Page %d, %d bookmarks:%s%s", cursor.Page+1, len(items), formatted, nextCursorNote(cursor, returned))
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(responseText),
+		), nil
+	}
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	cursor := searchCursor{Collection: 12, Page: 3, PerPage: searchPageSize, Search: `#go "error handling"`, ClientSort: "domain", ShowCollection: true}
+
+	decoded, err := decodeCursor(encodeCursor(cursor))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if decoded != cursor {
+		t.Errorf("Expected %+v, got %+v", cursor, decoded)
+	}
+
+	params := decoded.params()
+	if params.Get("page") != "3" || params.Get("perpage") != "25" || params.Get("search") != `#go "error handling"` {
+		t.Errorf("Unexpected params: %v", params)
+	}
+	if params.Has("nested") {
+		t.Errorf("Expected no nested flag for a single collection, got %v", params)
+	}
+
+	cursor.Nested = true
+	decoded, err = decodeCursor(encodeCursor(cursor))
+	if err != nil || !decoded.Nested || decoded.params().Get("nested") != "true" {
+		t.Errorf("Expected the nested flag to survive the round trip, got %+v (%v)", decoded, err)
+	}
+}
+
+func TestDecodeCursorInvalid(t *testing.T) {
+	encode := func(s string) string { return base64.RawURLEncoding.EncodeToString([]byte(s)) }
+
+	for _, token := range []string{
+		"not base64!",
+		encode("not json"),
+		encode(`{"collection": 0, "page": -1, "perpage": 25, "search": "go"}`),
+		encode(`{"collection": 0, "page": 0, "perpage": 500, "search": "go"}`),
+		encode(`{"collection": 0, "page": 0, "perpage": 25, "search": "go", "extra": true}`),
+		encode(`{"collection": 0, "page": 0, "perpage": 25, "search": "go", "clientSort": "random"}`),
+	} {
+		if _, err := decodeCursor(token); err == nil || !strings.HasPrefix(err.Error(), "invalid cursor") {
+			t.Errorf("Expected invalid cursor error for %q, got %v", token, err)
+		}
+	}
+}
+
+func TestNextCursorNote(t *testing.T) {
+	cursor := searchCursor{Page: 0, PerPage: 25, Search: "go"}
+
+	if note := nextCursorNote(cursor, 10); note != "" {
+		t.Errorf("Expected no cursor after a partial page, got %q", note)
+	}
+
+	note := nextCursorNote(cursor, 25)
+	token := note[strings.LastIndex(note, " ")+1:]
+	next, err := decodeCursor(token)
+	if err != nil || next.Page != 1 || next.Search != "go" {
+		t.Errorf("Expected cursor for page 1, got %+v, %v", next, err)
+	}
+}
+
+func TestSearchContinueKeepsSortAndCollection(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/raindrops/0":
+			if r.URL.Query().Get("page") != "1" {
+				t.Errorf("Expected page 1, got %s", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"items": [
+				{"_id": 1, "title": "B", "link": "https://b.example", "domain": "b.example", "collection": {"$id": 5}},
+				{"_id": 2, "title": "A", "link": "https://a.example", "domain": "a.example", "collection": {"$id": 5}}
+			]}`))
+		case "/collections":
+			w.Write([]byte(`{"items": [{"_id": 5, "title": "Reading"}]}`))
+		case "/collections/childrens":
+			w.Write([]byte(`{"items": []}`))
+		default:
+			t.Errorf("Unexpected request: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	// The cursor search-bookmarks hands out for page 2 of a sorted search.
+	first := searchCursor{PerPage: searchPageSize, Search: "go", ClientSort: "domain", ShowCollection: true}
+	note := nextCursorNote(first, searchPageSize)
+	token := note[strings.LastIndex(note, " ")+1:]
+
+	client := &RaindropClient{Token: "test-token", BaseURL: server.URL}
+	resp, err := searchContinueHandler(client)(context.Background(), SearchContinueArgs{Cursor: token})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	text := resp.Content[0].TextContent.Text
+	if strings.Index(text, "https://a.example") > strings.Index(text, "https://b.example") {
+		t.Errorf("Expected page 2 sorted by domain, got %q", text)
+	}
+	if !strings.Contains(text, "Collection: Reading") {
+		t.Errorf("Expected page 2 to show collections, got %q", text)
+	}
+}
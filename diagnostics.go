@@ -0,0 +1,38 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	mcp "github.com/metoro-io/mcp-golang"
+)
+
+type PingArgs struct{}
+
+func pingHandler(client *RaindropClient) func(ctx context.Context, args PingArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args PingArgs) (*mcp.ToolResponse, error) {
+		start := time.Now()
+		_, _, err := client.doRequest(ctx, "GET", "/user", nil, nil)
+		latency := time.Since(start).Milliseconds()
+
+		var report strings.Builder
+		if err != nil {
+			report.WriteString(fmt.Sprintf("Status: failed\nLatency: %d ms\nError: %v", latency, err))
+		} else {
+			report.WriteString(fmt.Sprintf("Status: ok\nLatency: %d ms", latency))
+		}
+
+		if rateLimit := client.RateLimit(); rateLimit.Seen {
+			report.WriteString(fmt.Sprintf("\nRate limit: %d of %d requests remaining", rateLimit.Remaining, rateLimit.Limit))
+			if !rateLimit.Reset.IsZero() {
+				report.WriteString(fmt.Sprintf(", resets at %s", rateLimit.Reset.UTC().Format(time.RFC3339)))
+			}
+		}
+
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(report.String()),
+		), nil
+	}
+}
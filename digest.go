@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	mcp "github.com/metoro-io/mcp-golang"
+)
+
+const (
+	// defaultDigestDays is the period weekly-digest covers when none is given.
+	defaultDigestDays = 7
+	// maxDigestItems caps how many bookmarks a digest lists.
+	maxDigestItems = 200
+	// unknownDigestDay heads the bookmarks whose creation date is unreadable.
+	unknownDigestDay = "Unknown date"
+)
+
+// renderDigest renders bookmarks as a Markdown digest with one section per day
+// they were saved on (UTC), newest day first. truncated notes that more
+// bookmarks were saved than are listed.
+func renderDigest(items []map[string]interface{}, days int, truncated bool) string {
+	var order []string
+	byDay := map[string][]map[string]interface{}{}
+	for _, item := range items {
+		day := unknownDigestDay
+		if created, err := parseDate(stringField(item, "created")); err == nil {
+			day = created.Format("2006-01-02 (Monday)")
+		}
+		if _, ok := byDay[day]; !ok {
+			order = append(order, day)
+		}
+		byDay[day] = append(byDay[day], item)
+	}
+	// Newest day first; "Unknown date" sorts after any date.
+	sort.SliceStable(order, func(i, j int) bool {
+		if order[i] == unknownDigestDay || order[j] == unknownDigestDay {
+			return order[j] == unknownDigestDay && order[i] != unknownDigestDay
+		}
+		return order[i] > order[j]
+	})
+
+	var doc strings.Builder
+	doc.WriteString(fmt.Sprintf("# Saved in the last %d days\n\n", days))
+	if truncated {
+		doc.WriteString(fmt.Sprintf("More than %d bookmarks; showing the latest %d.\n", len(items), len(items)))
+	} else {
+		doc.WriteString(fmt.Sprintf("%d bookmarks.\n", len(items)))
+	}
+	for _, day := range order {
+		doc.WriteString(fmt.Sprintf("\n## %s\n\n", day))
+		for _, item := range byDay[day] {
+			doc.WriteString(markdownLine(item, false))
+		}
+	}
+	return doc.String()
+}
+
+// createdSince returns the items created at or after since. The created:>
+// search only works in whole days, so it returns part of the day before too.
+// Items whose creation date is unreadable are kept.
+func createdSince(items []map[string]interface{}, since time.Time) []map[string]interface{} {
+	var kept []map[string]interface{}
+	for _, item := range items {
+		if created, err := parseDate(stringField(item, "created")); err == nil && created.Before(since) {
+			continue
+		}
+		kept = append(kept, item)
+	}
+	return kept
+}
+
+type WeeklyDigestArgs struct {
+	Collection int `json:"collection,omitempty" jsonschema:"description=Collection ID to summarize (default 0: all bookmarks)"`
+	Days       int `json:"days,omitempty" jsonschema:"description=How many days back to look (default 7)"`
+}
+
+func weeklyDigestHandler(client *RaindropClient) func(ctx context.Context, args WeeklyDigestArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args WeeklyDigestArgs) (*mcp.ToolResponse, error) {
+		days := args.Days
+		if days < 0 {
+			return nil, fmt.Errorf("days must be positive")
+		}
+		if days == 0 {
+			days = defaultDigestDays
+		}
+
+		// created:> is exclusive, so search from the day before the first day
+		// and drop what falls before the exact start afterwards.
+		since := time.Now().UTC().AddDate(0, 0, -days)
+		params := url.Values{}
+		params.Set("search", "created:>"+since.AddDate(0, 0, -1).Format("2006-01-02"))
+		params.Set("sort", "-created")
+
+		items, err := client.listRaindrops(ctx, args.Collection, params, maxDigestItems+1)
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+		items = createdSince(items, since)
+
+		if len(items) == 0 {
+			return mcp.NewToolResponse(
+				mcp.NewTextContent(fmt.Sprintf("No bookmarks were saved in the last %d days.", days)),
+			), nil
+		}
+
+		truncated := len(items) > maxDigestItems
+		if truncated {
+			items = items[:maxDigestItems]
+		}
+
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(renderDigest(items, days, truncated)),
+		), nil
+	}
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRenderDigest(t *testing.T) {
+	items := []map[string]interface{}{
+		{"title": "Old", "link": "https://a.example", "created": "2024-03-01T09:00:00.000Z"},
+		{"title": "Undated", "link": "https://b.example"},
+		{"title": "New", "link": "https://c.example", "created": "2024-03-04T18:30:00.000Z", "tags": []interface{}{"go"}},
+		{"title": "Same day", "link": "https://d.example", "created": "2024-03-01T21:00:00Z"},
+	}
+
+	digest := renderDigest(items, 7, false)
+
+	expected := "# Saved in the last 7 days\n\n4 bookmarks.\n" +
+		"\n## 2024-03-04 (Monday)\n\n- [New](https://c.example) — go\n" +
+		"\n## 2024-03-01 (Friday)\n\n- [Old](https://a.example)\n- [Same day](https://d.example)\n" +
+		"\n## Unknown date\n\n- [Undated](https://b.example)\n"
+	if digest != expected {
+		t.Errorf("Unexpected digest:\n%s\nexpected:\n%s", digest, expected)
+	}
+
+	if truncated := renderDigest(items[:1], 7, true); !strings.Contains(truncated, "More than 1 bookmarks; showing the latest 1.") {
+		t.Errorf("Expected truncation note, got:\n%s", truncated)
+	}
+}
+
+func TestCreatedSince(t *testing.T) {
+	since := time.Date(2024, 5, 8, 12, 0, 0, 0, time.UTC)
+	items := []map[string]interface{}{
+		{"_id": float64(1), "created": "2024-05-15T09:00:00Z"},
+		{"_id": float64(2), "created": "2024-05-08T12:00:00Z"},
+		{"_id": float64(3), "created": "2024-05-08T11:59:59Z"},
+		{"_id": float64(4), "created": "2024-05-07T20:00:00Z"},
+		{"_id": float64(5)},
+	}
+
+	var ids []int
+	for _, item := range createdSince(items, since) {
+		ids = append(ids, intField(item, "_id"))
+	}
+	if !reflect.DeepEqual(ids, []int{1, 2, 5}) {
+		t.Errorf("Expected bookmarks 1, 2 and 5, got %v", ids)
+	}
+}
@@ -0,0 +1,338 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/url"
+	"strings"
+	"time"
+
+	mcp "github.com/metoro-io/mcp-golang"
+)
+
+// maxExportItems caps how many raindrops a single export will page through.
+const maxExportItems = 1000
+
+// exportChunks renders a collection one page at a time, returning one content
+// chunk per page in collection order and the number of raindrops exported.
+// Only the rendered text of earlier pages is kept in memory, but the chunks are
+// all returned together once the last page is fetched; they split the output
+// into page-sized pieces within one response rather than deliver it sooner.
+func exportChunks(ctx context.Context, client *RaindropClient, collection int, maxItems int, render func(page []map[string]interface{}) (string, error)) ([]*mcp.Content, int, error) {
+	var chunks []*mcp.Content
+	count := 0
+	err := client.eachRaindropPage(ctx, collection, url.Values{}, maxItems, func(page []map[string]interface{}) error {
+		text, err := render(page)
+		if err != nil {
+			return err
+		}
+		chunks = append(chunks, mcp.NewTextContent(text))
+		count += len(page)
+		return nil
+	})
+	return chunks, count, err
+}
+
+type ExportMarkdownArgs struct {
+	Collection      int  `json:"collection" jsonschema:"description=Collection ID to export (0 for all bookmarks)"`
+	GroupByTag      bool `json:"groupByTag,omitempty" jsonschema:"description=Group bookmarks under a heading for their first tag"`
+	IncludeExcerpts bool `json:"includeExcerpts,omitempty" jsonschema:"description=Include excerpts as sub-bullets"`
+	MaxItems        int  `json:"maxItems,omitempty" jsonschema:"description=Maximum number of bookmarks to export (default and cap 1000)"`
+	Stream          bool `json:"stream,omitempty" jsonschema:"description=Split the output into one content chunk per page of bookmarks plus a completion marker; all chunks arrive together in one response (cannot be combined with groupByTag)"`
+}
+
+// exportLimit clamps a caller supplied item limit to maxExportItems.
+func exportLimit(maxItems int) int {
+	if maxItems <= 0 || maxItems > maxExportItems {
+		return maxExportItems
+	}
+	return maxItems
+}
+
+// markdownEscaper escapes characters that would break a Markdown link label.
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	`[`, `\[`,
+	`]`, `\]`,
+	`*`, `\*`,
+	`_`, `\_`,
+	"`", "\\`",
+)
+
+// markdownLine renders a raindrop as a Markdown list item.
+func markdownLine(bookmark map[string]interface{}, includeExcerpt bool) string {
+	title := stringField(bookmark, "title")
+	link := stringField(bookmark, "link")
+	if title == "" {
+		title = link
+	}
+
+	var line strings.Builder
+	line.WriteString(fmt.Sprintf("- [%s](%s)", markdownEscaper.Replace(title), strings.ReplaceAll(link, ")", "%29")))
+	if tags := extractTags(bookmark); len(tags) > 0 {
+		line.WriteString(" — " + strings.Join(tags, ", "))
+	}
+	line.WriteString("\n")
+
+	if includeExcerpt {
+		if excerpt := strings.TrimSpace(stringField(bookmark, "excerpt")); excerpt != "" {
+			excerpt = strings.Join(strings.Fields(excerpt), " ")
+			line.WriteString("  - " + markdownEscaper.Replace(excerpt) + "\n")
+		}
+	}
+
+	return line.String()
+}
+
+// renderMarkdown builds the Markdown document for an export.
+func renderMarkdown(items []map[string]interface{}, groupByTag bool, includeExcerpts bool) string {
+	var doc strings.Builder
+
+	if !groupByTag {
+		for _, item := range items {
+			doc.WriteString(markdownLine(item, includeExcerpts))
+		}
+		return doc.String()
+	}
+
+	var groups []string
+	grouped := map[string][]map[string]interface{}{}
+	var untagged []map[string]interface{}
+	for _, item := range items {
+		tags := extractTags(item)
+		if len(tags) == 0 {
+			untagged = append(untagged, item)
+			continue
+		}
+		if _, ok := grouped[tags[0]]; !ok {
+			groups = append(groups, tags[0])
+		}
+		grouped[tags[0]] = append(grouped[tags[0]], item)
+	}
+
+	for _, group := range groups {
+		doc.WriteString(fmt.Sprintf("## %s\n\n", group))
+		for _, item := range grouped[group] {
+			doc.WriteString(markdownLine(item, includeExcerpts))
+		}
+		doc.WriteString("\n")
+	}
+	if len(untagged) > 0 {
+		doc.WriteString("## Untagged\n\n")
+		for _, item := range untagged {
+			doc.WriteString(markdownLine(item, includeExcerpts))
+		}
+		doc.WriteString("\n")
+	}
+
+	return strings.TrimRight(doc.String(), "\n") + "\n"
+}
+
+func exportMarkdownHandler(client *RaindropClient) func(ctx context.Context, args ExportMarkdownArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args ExportMarkdownArgs) (*mcp.ToolResponse, error) {
+		if args.Stream && args.GroupByTag {
+			return nil, fmt.Errorf("stream cannot be combined with groupByTag")
+		}
+
+		ctx, cancel := client.bulkContext(ctx)
+		defer cancel()
+
+		if args.Stream {
+			chunks, count, err := exportChunks(ctx, client, args.Collection, exportLimit(args.MaxItems), func(page []map[string]interface{}) (string, error) {
+				return renderMarkdown(page, false, args.IncludeExcerpts), nil
+			})
+			if err != nil {
+				return nil, fmt.Errorf("internal error: %v", err)
+			}
+			chunks = append(chunks, mcp.NewTextContent(fmt.Sprintf("<!-- export complete: %d bookmarks -->\n", count)))
+			return mcp.NewToolResponse(chunks...), nil
+		}
+
+		items, err := client.listRaindrops(ctx, args.Collection, url.Values{}, exportLimit(args.MaxItems))
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+
+		if len(items) == 0 {
+			return mcp.NewToolResponse(
+				mcp.NewTextContent("No bookmarks found in this collection."),
+			), nil
+		}
+
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(renderMarkdown(items, args.GroupByTag, args.IncludeExcerpts)),
+		), nil
+	}
+}
+
+type ExportJSONArgs struct {
+	Collection int  `json:"collection" jsonschema:"description=Collection ID to export (0 for all bookmarks)"`
+	Stream     bool `json:"stream,omitempty" jsonschema:"description=Split the output into one JSON array chunk per page of bookmarks plus a completion summary; all chunks arrive together in one response"`
+}
+
+// jsonExport is the document produced by export-json and read by import-json.
+type jsonExport struct {
+	ExportedAt time.Time                `json:"exportedAt"`
+	Collection int                      `json:"collection"`
+	Count      int                      `json:"count"`
+	Items      []map[string]interface{} `json:"items"`
+}
+
+// exportJSON fetches up to maxExportItems raindrops of a collection as a jsonExport.
+func (r *RaindropClient) exportJSON(ctx context.Context, collection int) (jsonExport, error) {
+	items, err := r.listRaindrops(ctx, collection, url.Values{}, maxExportItems)
+	if err != nil {
+		return jsonExport{}, err
+	}
+	if items == nil {
+		items = []map[string]interface{}{}
+	}
+	return jsonExport{
+		ExportedAt: time.Now().UTC(),
+		Collection: collection,
+		Count:      len(items),
+		Items:      items,
+	}, nil
+}
+
+func exportJSONHandler(client *RaindropClient) func(ctx context.Context, args ExportJSONArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args ExportJSONArgs) (*mcp.ToolResponse, error) {
+		ctx, cancel := client.bulkContext(ctx)
+		defer cancel()
+
+		if args.Stream {
+			chunks, count, err := exportChunks(ctx, client, args.Collection, maxExportItems, func(page []map[string]interface{}) (string, error) {
+				output, err := json.MarshalIndent(page, "", "  ")
+				return string(output), err
+			})
+			if err != nil {
+				return nil, fmt.Errorf("internal error: %v", err)
+			}
+
+			// The final chunk is the export wrapper without items, marking completion.
+			summary, err := json.MarshalIndent(map[string]interface{}{
+				"exportedAt": time.Now().UTC(),
+				"collection": args.Collection,
+				"count":      count,
+				"complete":   true,
+			}, "", "  ")
+			if err != nil {
+				return nil, err
+			}
+			chunks = append(chunks, mcp.NewTextContent(string(summary)))
+			return mcp.NewToolResponse(chunks...), nil
+		}
+
+		export, err := client.exportJSON(ctx, args.Collection)
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+		return jsonResponse(export)
+	}
+}
+
+type ExportHTMLArgs struct {
+	Collection       int  `json:"collection" jsonschema:"description=Collection ID to export (0 for all bookmarks)"`
+	NestByCollection bool `json:"nestByCollection,omitempty" jsonschema:"description=Put bookmarks in a folder per collection"`
+}
+
+// netscapeHeader starts a Netscape bookmarks file, the format browsers import.
+const netscapeHeader = `<!DOCTYPE NETSCAPE-Bookmark-file-1>
+<!-- This is an automatically generated file. -->
+<META HTTP-EQUIV="Content-Type" CONTENT="text/html; charset=UTF-8">
+<TITLE>Bookmarks</TITLE>
+<H1>Bookmarks</H1>
+`
+
+// netscapeEntry renders a raindrop as a <DT><A> entry indented by indent.
+func netscapeEntry(bookmark map[string]interface{}, indent string) string {
+	title := stringField(bookmark, "title")
+	link := stringField(bookmark, "link")
+	if title == "" {
+		title = link
+	}
+
+	var entry strings.Builder
+	entry.WriteString(fmt.Sprintf(`%s<DT><A HREF="%s"`, indent, html.EscapeString(link)))
+	if created, err := parseDate(stringField(bookmark, "created")); err == nil {
+		entry.WriteString(fmt.Sprintf(` ADD_DATE="%d"`, created.Unix()))
+	}
+	if tags := extractTags(bookmark); len(tags) > 0 {
+		entry.WriteString(fmt.Sprintf(` TAGS="%s"`, html.EscapeString(strings.Join(tags, ","))))
+	}
+	entry.WriteString(fmt.Sprintf(">%s</A>\n", html.EscapeString(title)))
+	if excerpt := strings.Join(strings.Fields(stringField(bookmark, "excerpt")), " "); excerpt != "" {
+		entry.WriteString(fmt.Sprintf("%s<DD>%s\n", indent, html.EscapeString(excerpt)))
+	}
+	return entry.String()
+}
+
+// renderNetscapeHTML builds a Netscape bookmarks file. When folders is non-nil,
+// bookmarks are grouped into a folder per collection named by folders[id].
+func renderNetscapeHTML(items []map[string]interface{}, folders map[int]string) string {
+	var doc strings.Builder
+	doc.WriteString(netscapeHeader)
+	doc.WriteString("<DL><p>\n")
+
+	if folders == nil {
+		for _, item := range items {
+			doc.WriteString(netscapeEntry(item, "    "))
+		}
+	} else {
+		var order []int
+		grouped := map[int][]map[string]interface{}{}
+		for _, item := range items {
+			id := collectionIDOf(item)
+			if _, ok := grouped[id]; !ok {
+				order = append(order, id)
+			}
+			grouped[id] = append(grouped[id], item)
+		}
+
+		for _, id := range order {
+			name, ok := folders[id]
+			if !ok {
+				name = fmt.Sprintf("Collection %d", id)
+			}
+			doc.WriteString(fmt.Sprintf("    <DT><H3>%s</H3>\n    <DL><p>\n", html.EscapeString(name)))
+			for _, item := range grouped[id] {
+				doc.WriteString(netscapeEntry(item, "        "))
+			}
+			doc.WriteString("    </DL><p>\n")
+		}
+	}
+
+	doc.WriteString("</DL><p>\n")
+	return doc.String()
+}
+
+func exportHTMLHandler(client *RaindropClient) func(ctx context.Context, args ExportHTMLArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args ExportHTMLArgs) (*mcp.ToolResponse, error) {
+		ctx, cancel := client.bulkContext(ctx)
+		defer cancel()
+
+		items, err := client.listRaindrops(ctx, args.Collection, url.Values{}, maxExportItems)
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+
+		var folders map[int]string
+		if args.NestByCollection {
+			collections, err := client.fetchCollections(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("internal error: %v", err)
+			}
+			folders = map[int]string{}
+			for _, item := range items {
+				id := collectionIDOf(item)
+				folders[id] = strings.Join(collectionPath(collections, id), " > ")
+			}
+		}
+
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(renderNetscapeHTML(items, folders)),
+		), nil
+	}
+}
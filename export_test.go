@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestRenderMarkdown(t *testing.T) {
+	items := []map[string]interface{}{
+		{"title": "Go [blog]", "link": "https://go.dev/blog", "tags": []interface{}{"go", "blog"}, "excerpt": "The Go\nblog"},
+		{"title": "", "link": "https://example.com"},
+		{"title": "Rust", "link": "https://rust-lang.org", "tags": []interface{}{"rust"}},
+	}
+
+	flat := renderMarkdown(items, false, true)
+	expected := "- [Go \\[blog\\]](https://go.dev/blog) — go, blog\n" +
+		"  - The Go blog\n" +
+		"- [https://example.com](https://example.com)\n" +
+		"- [Rust](https://rust-lang.org) — rust\n"
+	if flat != expected {
+		t.Errorf("Unexpected flat markdown:\n%s", flat)
+	}
+
+	grouped := renderMarkdown(items, true, false)
+	if !strings.HasPrefix(grouped, "## go\n\n- [Go \\[blog\\]]") {
+		t.Errorf("Expected go group first, got:\n%s", grouped)
+	}
+	if !strings.Contains(grouped, "## Untagged\n\n- [https://example.com]") {
+		t.Errorf("Expected untagged group, got:\n%s", grouped)
+	}
+	if strings.Contains(grouped, "  - The Go blog") {
+		t.Errorf("Expected no excerpts, got:\n%s", grouped)
+	}
+}
+
+func TestExportLimit(t *testing.T) {
+	tests := map[int]int{0: maxExportItems, -5: maxExportItems, 10: 10, maxExportItems + 1: maxExportItems}
+	for input, expected := range tests {
+		if got := exportLimit(input); got != expected {
+			t.Errorf("exportLimit(%d) = %d, expected %d", input, got, expected)
+		}
+	}
+}
+
+func TestRenderNetscapeHTML(t *testing.T) {
+	items := []map[string]interface{}{
+		{"title": `Tom & "Jerry"`, "link": "https://example.com/?a=1&b=2", "tags": []interface{}{"fun", "tv"}, "created": "2021-01-02T03:04:05Z", "collection": map[string]interface{}{"$id": float64(5)}},
+		{"title": "", "link": "https://go.dev", "excerpt": "The Go\nwebsite", "collection": map[string]interface{}{"$id": float64(-1)}},
+	}
+
+	doc := renderNetscapeHTML(items, nil)
+	for _, expected := range []string{
+		"<!DOCTYPE NETSCAPE-Bookmark-file-1>",
+		`    <DT><A HREF="https://example.com/?a=1&amp;b=2" ADD_DATE="1609556645" TAGS="fun,tv">Tom &amp; &#34;Jerry&#34;</A>` + "\n",
+		`    <DT><A HREF="https://go.dev">https://go.dev</A>` + "\n    <DD>The Go website\n",
+	} {
+		if !strings.Contains(doc, expected) {
+			t.Errorf("Expected export to contain %q, got:\n%s", expected, doc)
+		}
+	}
+	if !strings.HasSuffix(doc, "</DL><p>\n") {
+		t.Errorf("Expected closing list, got:\n%s", doc)
+	}
+
+	nested := renderNetscapeHTML(items, map[int]string{5: "Fun > TV"})
+	for _, expected := range []string{
+		"    <DT><H3>Fun &gt; TV</H3>\n    <DL><p>\n        <DT><A HREF=\"https://example.com/?a=1&amp;b=2\"",
+		"    <DT><H3>Collection -1</H3>",
+	} {
+		if !strings.Contains(nested, expected) {
+			t.Errorf("Expected nested export to contain %q, got:\n%s", expected, nested)
+		}
+	}
+}
+
+func TestExportChunks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		size := raindropPageSize
+		if page == 1 {
+			size = 3
+		}
+		var items []map[string]interface{}
+		for i := 0; i < size; i++ {
+			items = append(items, map[string]interface{}{"_id": page*raindropPageSize + i, "link": fmt.Sprintf("https://example.com/%d", page*raindropPageSize+i)})
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"result": true, "items": items})
+	}))
+	defer server.Close()
+
+	client := &RaindropClient{Token: "test-token", BaseURL: server.URL}
+	chunks, count, err := exportChunks(context.Background(), client, 0, maxExportItems, func(page []map[string]interface{}) (string, error) {
+		return fmt.Sprintf("%d-%d", intField(page[0], "_id"), intField(page[len(page)-1], "_id")), nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if count != raindropPageSize+3 {
+		t.Errorf("Expected %d bookmarks, got %d", raindropPageSize+3, count)
+	}
+	if len(chunks) != 2 || chunks[0].TextContent.Text != "0-49" || chunks[1].TextContent.Text != "50-52" {
+		t.Errorf("Expected two ordered chunks, got %d", len(chunks))
+		for _, chunk := range chunks {
+			t.Logf("chunk: %s", chunk.TextContent.Text)
+		}
+	}
+}
@@ -0,0 +1,367 @@
+package main
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	mcp "github.com/metoro-io/mcp-golang"
+)
+
+type ExportFeedArgs struct {
+	Collection int    `json:"collection" jsonschema:"description=Collection ID to export (0 for all bookmarks)"`
+	Format     string `json:"format,omitempty" jsonschema:"enum=rss,enum=atom,description=Feed format (default rss)"`
+	MaxItems   int    `json:"maxItems,omitempty" jsonschema:"description=Maximum number of bookmarks to include (default and cap 1000)"`
+}
+
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title       string    `xml:"title"`
+	Link        string    `xml:"link"`
+	Description string    `xml:"description"`
+	Items       []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string  `xml:"title"`
+	Link        string  `xml:"link"`
+	Description string  `xml:"description,omitempty"`
+	PubDate     string  `xml:"pubDate,omitempty"`
+	GUID        rssGUID `xml:"guid"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type atomFeed struct {
+	XMLName xml.Name `xml:"http://www.w3.org/2005/Atom feed"`
+	Title   string   `xml:"title"`
+	ID      string   `xml:"id"`
+	Updated string   `xml:"updated"`
+	// Author is required by RFC 4287 because the entries have none.
+	Author  atomAuthor  `xml:"author"`
+	Link    atomLink    `xml:"link"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomEntry struct {
+	Title   string   `xml:"title"`
+	ID      string   `xml:"id"`
+	Updated string   `xml:"updated"`
+	Link    atomLink `xml:"link"`
+	Summary string   `xml:"summary,omitempty"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+}
+
+// collectionURL is the Raindrop web app address of a collection.
+func collectionURL(collection int) string {
+	return fmt.Sprintf("https://app.raindrop.io/my/%d", collection)
+}
+
+// renderFeed builds an RSS 2.0 or Atom document for the given raindrops.
+func renderFeed(format string, title string, collection int, items []map[string]interface{}, now time.Time) (string, error) {
+	var feed interface{}
+
+	switch format {
+	case "rss":
+		channel := rssChannel{
+			Title:       title,
+			Link:        collectionURL(collection),
+			Description: fmt.Sprintf("Bookmarks from the Raindrop.io collection %q", title),
+		}
+		for _, item := range items {
+			entry := rssItem{
+				Title:       stringField(item, "title"),
+				Link:        stringField(item, "link"),
+				Description: stringField(item, "excerpt"),
+				GUID:        rssGUID{Value: fmt.Sprintf("raindrop-%d", intField(item, "_id"))},
+			}
+			if created, err := time.Parse(time.RFC3339, stringField(item, "created")); err == nil {
+				entry.PubDate = created.Format(time.RFC1123Z)
+			}
+			channel.Items = append(channel.Items, entry)
+		}
+		feed = rssFeed{Version: "2.0", Channel: channel}
+	case "atom":
+		atom := atomFeed{
+			Title:   title,
+			ID:      collectionURL(collection),
+			Updated: now.UTC().Format(time.RFC3339),
+			Author:  atomAuthor{Name: "Raindrop.io"},
+			Link:    atomLink{Href: collectionURL(collection)},
+		}
+		for _, item := range items {
+			updated := stringField(item, "lastUpdate")
+			if updated == "" {
+				updated = stringField(item, "created")
+			}
+			if updated == "" {
+				updated = atom.Updated
+			}
+			atom.Entries = append(atom.Entries, atomEntry{
+				Title:   stringField(item, "title"),
+				ID:      fmt.Sprintf("%s/item/%d", collectionURL(collection), intField(item, "_id")),
+				Updated: updated,
+				Link:    atomLink{Href: stringField(item, "link")},
+				Summary: stringField(item, "excerpt"),
+			})
+		}
+		feed = atom
+	default:
+		return "", fmt.Errorf("invalid format %q: must be rss or atom", format)
+	}
+
+	output, err := xml.MarshalIndent(feed, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return xml.Header + string(output) + "\n", nil
+}
+
+func exportFeedHandler(client *RaindropClient) func(ctx context.Context, args ExportFeedArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args ExportFeedArgs) (*mcp.ToolResponse, error) {
+		format := args.Format
+		if format == "" {
+			format = "rss"
+		}
+		if format != "rss" && format != "atom" {
+			return nil, fmt.Errorf("invalid format %q: must be rss or atom", format)
+		}
+
+		ctx, cancel := client.bulkContext(ctx)
+		defer cancel()
+
+		title, err := client.collectionTitle(ctx, args.Collection)
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+
+		items, err := client.listRaindrops(ctx, args.Collection, url.Values{}, exportLimit(args.MaxItems))
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+
+		feed, err := renderFeed(format, title, args.Collection, items, time.Now())
+		if err != nil {
+			return nil, err
+		}
+
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(feed),
+		), nil
+	}
+}
+
+const (
+	// feedTimeout bounds fetching a feed for save-feed.
+	feedTimeout = 15 * time.Second
+	// maxFeedBytes is the largest feed document save-feed reads.
+	maxFeedBytes = 5 << 20
+	// defaultFeedEntries and maxFeedEntries bound how many entries save-feed saves.
+	defaultFeedEntries = 10
+	maxFeedEntries     = 100
+)
+
+// feedEntry is a single entry of an RSS or Atom feed.
+type feedEntry struct {
+	Title string
+	Link  string
+}
+
+// feedDocument covers RSS 2.0 (<channel><item>), RSS 1.0 (<item> directly under
+// <rdf:RDF>) and Atom (<entry>); only the fields save-feed needs are decoded.
+type feedDocument struct {
+	Channel struct {
+		Items []rssItem `xml:"item"`
+	} `xml:"channel"`
+	Items   []rssItem `xml:"item"`
+	Entries []struct {
+		Title string     `xml:"title"`
+		Links []atomLink `xml:"link"`
+	} `xml:"entry"`
+}
+
+// parseFeed returns the entries of an RSS or Atom document in document order,
+// skipping entries without a usable http(s) link.
+func parseFeed(data []byte) ([]feedEntry, error) {
+	var doc feedDocument
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("unable to parse feed: %v", err)
+	}
+
+	var entries []feedEntry
+	add := func(title, link string) {
+		link = strings.TrimSpace(link)
+		if validateURL(link) != nil {
+			return
+		}
+		entries = append(entries, feedEntry{Title: strings.Join(strings.Fields(title), " "), Link: link})
+	}
+
+	for _, item := range append(doc.Channel.Items, doc.Items...) {
+		link := item.Link
+		if strings.TrimSpace(link) == "" {
+			// Some feeds only carry the permalink as the guid.
+			link = item.GUID.Value
+		}
+		add(item.Title, link)
+	}
+	for _, entry := range doc.Entries {
+		var link string
+		for _, l := range entry.Links {
+			if l.Rel == "" || l.Rel == "alternate" {
+				link = l.Href
+				break
+			}
+		}
+		add(entry.Title, link)
+	}
+	return entries, nil
+}
+
+// fetchFeed downloads and parses the feed at feedURL, giving up after feedTimeout.
+func fetchFeed(ctx context.Context, feedURL string) ([]feedEntry, error) {
+	ctx, cancel := context.WithTimeout(ctx, feedTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", feedURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/rss+xml, application/atom+xml, application/xml;q=0.9, */*;q=0.8")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return nil, fmt.Errorf("timed out after %s", feedTimeout)
+		}
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("feed error: %s", resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxFeedBytes))
+	if err != nil {
+		return nil, err
+	}
+	return parseFeed(data)
+}
+
+type SaveFeedArgs struct {
+	FeedURL    string   `json:"feedUrl" jsonschema:"required,description=URL of the RSS or Atom feed"`
+	Collection int      `json:"collection,omitempty" jsonschema:"description=Collection ID to save into (default Unsorted)"`
+	Limit      int      `json:"limit,omitempty" jsonschema:"description=How many of the latest entries to save (default 10, max 100)"`
+	Tags       []string `json:"tags,omitempty" jsonschema:"description=Tags to add to every saved entry"`
+	JSON       bool     `json:"json,omitempty" jsonschema:"description=Return the result as JSON"`
+}
+
+func saveFeedHandler(client *RaindropClient) func(ctx context.Context, args SaveFeedArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args SaveFeedArgs) (*mcp.ToolResponse, error) {
+		if err := validateURL(args.FeedURL); err != nil {
+			return nil, err
+		}
+		limit := args.Limit
+		if limit <= 0 {
+			limit = defaultFeedEntries
+		}
+		limit = min(limit, maxFeedEntries)
+
+		entries, err := fetchFeed(ctx, args.FeedURL)
+		if err != nil {
+			return nil, fmt.Errorf("unable to fetch feed: %v", err)
+		}
+		if len(entries) == 0 {
+			return mcp.NewToolResponse(
+				mcp.NewTextContent(fmt.Sprintf("The feed at %s has no entries with links.", args.FeedURL)),
+			), nil
+		}
+		// Feeds list their newest entries first.
+		if len(entries) > limit {
+			entries = entries[:limit]
+		}
+
+		collection := args.Collection
+		if collection == 0 {
+			collection = unsortedCollectionID
+		}
+
+		ctx, cancel := client.bulkContext(ctx)
+		defer cancel()
+
+		// Check every collection, not just the target, so an entry saved
+		// elsewhere is not saved again. Only the most recent saves are listed.
+		existing, err := client.listRaindrops(ctx, 0, url.Values{}, maxExportItems)
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+		saved := map[string]bool{}
+		for _, item := range existing {
+			saved[normalizeURL(stringField(item, "link"))] = true
+		}
+
+		var report strings.Builder
+		var fresh []feedEntry
+		var items []map[string]interface{}
+		alreadySaved := []string{}
+		for _, entry := range entries {
+			key := normalizeURL(entry.Link)
+			if saved[key] {
+				alreadySaved = append(alreadySaved, entry.Link)
+				report.WriteString(fmt.Sprintf("\nAlready saved: %s", entry.Link))
+				continue
+			}
+			saved[key] = true
+			fresh = append(fresh, entry)
+			item := map[string]interface{}{
+				"link":       entry.Link,
+				"tags":       mergeTags(args.Tags),
+				"collection": map[string]interface{}{"$id": collection},
+			}
+			if entry.Title != "" {
+				item["title"] = entry.Title
+			}
+			items = append(items, item)
+		}
+
+		created, result := client.createRaindrops(ctx, items, false)
+		if args.JSON {
+			return jsonResponse(map[string]interface{}{
+				"alreadySaved": alreadySaved,
+				"result":       result,
+			})
+		}
+		for _, bookmark := range created {
+			report.WriteString(fmt.Sprintf("\nSaved: %s", stringField(bookmark, "link")))
+		}
+		report.WriteString(result.FailedText())
+
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(fmt.Sprintf("Saved %d of the latest %d feed entries (%d already saved):%s",
+				len(created), len(entries), len(entries)-len(fresh), report.String())),
+		), nil
+	}
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRenderFeed(t *testing.T) {
+	items := []map[string]interface{}{
+		{
+			"_id":     float64(7),
+			"title":   "Tom & Jerry <3",
+			"link":    "https://example.com/?a=1&b=2",
+			"excerpt": "Cartoons",
+			"created": "2024-01-02T03:04:05Z",
+		},
+	}
+	now := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	rss, err := renderFeed("rss", "Reading", 42, items, now)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, expected := range []string{
+		`<rss version="2.0">`,
+		"<title>Tom &amp; Jerry &lt;3</title>",
+		"<link>https://example.com/?a=1&amp;b=2</link>",
+		"<pubDate>Tue, 02 Jan 2024 03:04:05 +0000</pubDate>",
+		`<guid isPermaLink="false">raindrop-7</guid>`,
+	} {
+		if !strings.Contains(rss, expected) {
+			t.Errorf("Expected RSS to contain %q, got:\n%s", expected, rss)
+		}
+	}
+
+	atom, err := renderFeed("atom", "Reading", 42, items, now)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var parsed atomFeed
+	if err := xml.Unmarshal([]byte(atom), &parsed); err != nil {
+		t.Fatalf("Atom feed is not valid XML: %v", err)
+	}
+	if parsed.Author.Name != "Raindrop.io" {
+		t.Errorf("Expected the feed to name an author, got %+v", parsed.Author)
+	}
+	if len(parsed.Entries) != 1 || parsed.Entries[0].Link.Href != "https://example.com/?a=1&b=2" {
+		t.Errorf("Unexpected atom entries: %+v", parsed.Entries)
+	}
+	if parsed.Entries[0].Updated != "2024-01-02T03:04:05Z" {
+		t.Errorf("Expected entry updated from created date, got %s", parsed.Entries[0].Updated)
+	}
+
+	if _, err := renderFeed("json", "Reading", 42, items, now); err == nil {
+		t.Error("Expected error for unsupported format")
+	}
+}
+
+func TestParseFeedRSS(t *testing.T) {
+	rss := `<?xml version="1.0"?>
+<rss version="2.0"><channel><title>Blog</title>
+<item><title>First
+  post</title><link>https://blog.example/1</link></item>
+<item><title>Guid only</title><guid>https://blog.example/2</guid></item>
+<item><title>No link</title><guid isPermaLink="false">tag:blog,2</guid></item>
+</channel></rss>`
+
+	entries, err := parseFeed([]byte(rss))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := []feedEntry{
+		{Title: "First post", Link: "https://blog.example/1"},
+		{Title: "Guid only", Link: "https://blog.example/2"},
+	}
+	if !reflect.DeepEqual(entries, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, entries)
+	}
+}
+
+func TestParseFeedAtom(t *testing.T) {
+	atom := `<feed xmlns="http://www.w3.org/2005/Atom"><title>News</title>
+<entry><title>Hello</title>
+  <link rel="self" href="https://news.example/api/1"/>
+  <link rel="alternate" href="https://news.example/1"/></entry>
+<entry><title>Plain</title><link href="https://news.example/2"/></entry>
+</feed>`
+
+	entries, err := parseFeed([]byte(atom))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := []feedEntry{
+		{Title: "Hello", Link: "https://news.example/1"},
+		{Title: "Plain", Link: "https://news.example/2"},
+	}
+	if !reflect.DeepEqual(entries, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, entries)
+	}
+
+	if _, err := parseFeed([]byte("<html><body>not a feed")); err == nil {
+		t.Error("Expected error for malformed XML")
+	}
+}
+
+func TestFetchFeedStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	_, err := fetchFeed(context.Background(), server.URL)
+	if err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("Expected a 404 error, got %v", err)
+	}
+}
+
+func TestSaveFeedSkipsEntriesSavedElsewhere(t *testing.T) {
+	var created []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/feed.xml":
+			w.Write([]byte(`<rss version="2.0"><channel><title>News</title>
+				<item><title>Old</title><link>https://example.com/old</link></item>
+				<item><title>New</title><link>https://example.com/new</link></item>
+			</channel></rss>`))
+		case r.Method == "GET" && r.URL.Path == "/raindrops/0":
+			w.Write([]byte(`{"items": [{"_id": 1, "link": "https://example.com/old", "collection": {"$id": 9}}]}`))
+		case r.Method == "POST" && r.URL.Path == "/raindrops":
+			var body struct {
+				Items []map[string]interface{} `json:"items"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			created = body.Items
+			json.NewEncoder(w).Encode(map[string]interface{}{"items": body.Items})
+		default:
+			t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := &RaindropClient{Token: "test-token", BaseURL: server.URL}
+	if _, err := saveFeedHandler(client)(context.Background(), SaveFeedArgs{FeedURL: server.URL + "/feed.xml"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(created) != 1 || created[0]["link"] != "https://example.com/new" {
+		t.Fatalf("Expected only the new entry to be saved, got %v", created)
+	}
+	if collection, _ := created[0]["collection"].(map[string]interface{}); intField(collection, "$id") != unsortedCollectionID {
+		t.Errorf("Expected the entry to go to Unsorted, got %v", created[0]["collection"])
+	}
+}
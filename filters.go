@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	mcp "github.com/metoro-io/mcp-golang"
+)
+
+// collectionFilters holds the counts behind Raindrop's built-in filters for a collection.
+type collectionFilters struct {
+	Important  int        `json:"important"`
+	Untagged   int        `json:"untagged"`
+	Broken     int        `json:"broken"`
+	Duplicates int        `json:"duplicates"`
+	Highlights int        `json:"highlights"`
+	Types      []tagCount `json:"types"`
+	Tags       []tagCount `json:"tags"`
+}
+
+// filterCount reads the count of a named filter object such as {"broken": {"count": 3}}.
+func filterCount(result map[string]interface{}, name string) int {
+	if filter, ok := result[name].(map[string]interface{}); ok {
+		return intField(filter, "count")
+	}
+	return 0
+}
+
+// filterCounts reads a list of {"_id": name, "count": n} entries.
+func filterCounts(result map[string]interface{}, name string) []tagCount {
+	counts := []tagCount{}
+	if entries, ok := result[name].([]interface{}); ok {
+		for _, entry := range entries {
+			if e, ok := entry.(map[string]interface{}); ok {
+				counts = append(counts, tagCount{Tag: stringField(e, "_id"), Count: intField(e, "count")})
+			}
+		}
+	}
+	return counts
+}
+
+// fetchFilters returns the filter counts for a collection (0 for all bookmarks).
+func (r *RaindropClient) fetchFilters(ctx context.Context, collection int) (*collectionFilters, error) {
+	result, err := r.MakeRequest(ctx, fmt.Sprintf("/filters/%d", collection), "GET", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &collectionFilters{
+		Important:  filterCount(result, "important"),
+		Untagged:   filterCount(result, "notag"),
+		Broken:     filterCount(result, "broken"),
+		Duplicates: filterCount(result, "duplicates"),
+		Highlights: filterCount(result, "highlights"),
+		Types:      filterCounts(result, "types"),
+		Tags:       filterCounts(result, "tags"),
+	}, nil
+}
+
+type ListFiltersArgs struct {
+	Collection int  `json:"collection,omitempty" jsonschema:"description=Collection ID (0 for all bookmarks)"`
+	JSON       bool `json:"json,omitempty" jsonschema:"description=Return the result as JSON"`
+}
+
+func listFiltersHandler(client *RaindropClient) func(ctx context.Context, args ListFiltersArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args ListFiltersArgs) (*mcp.ToolResponse, error) {
+		filters, err := client.fetchFilters(ctx, args.Collection)
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+
+		if args.JSON {
+			return jsonResponse(filters)
+		}
+
+		var report strings.Builder
+		report.WriteString(fmt.Sprintf("Filters for collection %d:\n", args.Collection))
+		report.WriteString(fmt.Sprintf("Favorites: %d\n", filters.Important))
+		report.WriteString(fmt.Sprintf("Untagged: %d\n", filters.Untagged))
+		report.WriteString(fmt.Sprintf("Broken links: %d\n", filters.Broken))
+		report.WriteString(fmt.Sprintf("Duplicates: %d\n", filters.Duplicates))
+		report.WriteString(fmt.Sprintf("With highlights: %d", filters.Highlights))
+		if len(filters.Types) > 0 {
+			report.WriteString("\nTypes:")
+			for _, t := range filters.Types {
+				report.WriteString(fmt.Sprintf("\n  %s: %d", t.Tag, t.Count))
+			}
+		}
+
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(report.String()),
+		), nil
+	}
+}
+
+// savedFilters maps the names run-saved-filter accepts to the Raindrop search
+// behind each built-in filter.
+var savedFilters = map[string]string{
+	"favorites":   "❤️",
+	"untagged":    "notag:true",
+	"broken":      "broken:true",
+	"duplicates":  "duplicate:true",
+	"highlighted": "highlights:true",
+	"files":       "file:true",
+	"reminders":   "reminder:true",
+}
+
+// savedFilterNames returns the names in savedFilters in alphabetical order.
+func savedFilterNames() []string {
+	names := make([]string, 0, len(savedFilters))
+	for name := range savedFilters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+type RunSavedFilterArgs struct {
+	Name       string `json:"name" jsonschema:"required,description=Filter to run: favorites, untagged, broken, duplicates, highlighted, files or reminders"`
+	Collection int    `json:"collection,omitempty" jsonschema:"description=Collection ID (0 for all bookmarks)"`
+}
+
+func runSavedFilterHandler(client *RaindropClient) func(ctx context.Context, args RunSavedFilterArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args RunSavedFilterArgs) (*mcp.ToolResponse, error) {
+		name := strings.ToLower(strings.TrimSpace(args.Name))
+		search, ok := savedFilters[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown filter %q; valid filters: %s", args.Name, strings.Join(savedFilterNames(), ", "))
+		}
+
+		cursor := searchCursor{Collection: args.Collection, Page: 0, PerPage: searchPageSize, Search: search}
+		items, returned, err := client.searchPage(ctx, cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(items) == 0 {
+			return mcp.NewToolResponse(
+				mcp.NewTextContent(fmt.Sprintf("No bookmarks match the %s filter (%s).", name, search)),
+			), nil
+		}
+
+		responseText := fmt.Sprintf("Bookmarks matching the %s filter (%s):%s%s", name, search, formatBookmarks(items), nextCursorNote(cursor, returned))
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(responseText),
+		), nil
+	}
+}
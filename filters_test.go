@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFilterCounts(t *testing.T) {
+	result := map[string]interface{}{
+		"broken": map[string]interface{}{"count": float64(3)},
+		"types": []interface{}{
+			map[string]interface{}{"_id": "article", "count": float64(5)},
+			map[string]interface{}{"_id": "video", "count": float64(2)},
+		},
+	}
+
+	if got := filterCount(result, "broken"); got != 3 {
+		t.Errorf("Expected 3 broken, got %d", got)
+	}
+	if got := filterCount(result, "duplicates"); got != 0 {
+		t.Errorf("Expected 0 duplicates, got %d", got)
+	}
+
+	expected := []tagCount{{Tag: "article", Count: 5}, {Tag: "video", Count: 2}}
+	if got := filterCounts(result, "types"); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+	if got := filterCounts(result, "tags"); got == nil || len(got) != 0 {
+		t.Errorf("Expected empty tags, got %v", got)
+	}
+}
+
+func TestRunSavedFilter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/raindrops/7" {
+			t.Errorf("Unexpected path: %s", r.URL.Path)
+		}
+		if search := r.URL.Query().Get("search"); search != "notag:true" {
+			t.Errorf("Expected the untagged search, got %q", search)
+		}
+		w.Write([]byte(`{"result": true, "items": [{"_id": 42, "title": "Loose end", "link": "https://example.com"}]}`))
+	}))
+	defer server.Close()
+
+	client := &RaindropClient{Token: "test-token", BaseURL: server.URL}
+	handler := runSavedFilterHandler(client)
+
+	resp, err := handler(context.Background(), RunSavedFilterArgs{Name: "Untagged", Collection: 7})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if text := resp.Content[0].TextContent.Text; !strings.Contains(text, "42") || strings.Contains(text, "nextCursor") {
+		t.Errorf("Unexpected response: %s", text)
+	}
+
+	_, err = handler(context.Background(), RunSavedFilterArgs{Name: "recent"})
+	if err == nil || !strings.Contains(err.Error(), "broken, duplicates, favorites") {
+		t.Errorf("Expected an error listing the valid filters, got %v", err)
+	}
+}
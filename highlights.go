@@ -0,0 +1,174 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	mcp "github.com/metoro-io/mcp-golang"
+)
+
+// highlightsOf returns the highlights saved on a raindrop.
+func highlightsOf(bookmark map[string]interface{}) []map[string]interface{} {
+	raw, _ := bookmark["highlights"].([]interface{})
+	var highlights []map[string]interface{}
+	for _, item := range raw {
+		if highlight, ok := item.(map[string]interface{}); ok {
+			highlights = append(highlights, highlight)
+		}
+	}
+	return highlights
+}
+
+// highlightedBookmark is a bookmark listed by find-highlighted.
+type highlightedBookmark struct {
+	ID         int    `json:"id"`
+	Title      string `json:"title"`
+	Link       string `json:"link"`
+	Highlights int    `json:"highlights"`
+}
+
+// fetchHighlighted returns the raindrops in a collection that have highlights.
+// The highlights:true search narrows the listing; the check on each item guards
+// against the search index lagging behind deleted highlights.
+func (r *RaindropClient) fetchHighlighted(ctx context.Context, collection int) ([]map[string]interface{}, error) {
+	params := url.Values{}
+	params.Set("search", "highlights:true")
+
+	items, err := r.listRaindrops(ctx, collection, params, maxExportItems)
+	if err != nil {
+		return nil, err
+	}
+
+	var highlighted []map[string]interface{}
+	for _, item := range items {
+		if len(highlightsOf(item)) > 0 {
+			highlighted = append(highlighted, item)
+		}
+	}
+	return highlighted, nil
+}
+
+type FindHighlightedArgs struct {
+	Collection int  `json:"collection,omitempty" jsonschema:"description=Collection ID (0 for all bookmarks)"`
+	JSON       bool `json:"json,omitempty" jsonschema:"description=Return the result as JSON"`
+}
+
+func findHighlightedHandler(client *RaindropClient) func(ctx context.Context, args FindHighlightedArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args FindHighlightedArgs) (*mcp.ToolResponse, error) {
+		ctx, cancel := client.bulkContext(ctx)
+		defer cancel()
+
+		items, err := client.fetchHighlighted(ctx, args.Collection)
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+
+		bookmarks := []highlightedBookmark{}
+		total := 0
+		for _, item := range items {
+			count := len(highlightsOf(item))
+			total += count
+			bookmarks = append(bookmarks, highlightedBookmark{
+				ID:         intField(item, "_id"),
+				Title:      stringField(item, "title"),
+				Link:       stringField(item, "link"),
+				Highlights: count,
+			})
+		}
+
+		if args.JSON {
+			return jsonResponse(map[string]interface{}{
+				"bookmarks":  bookmarks,
+				"highlights": total,
+			})
+		}
+
+		if len(bookmarks) == 0 {
+			return mcp.NewToolResponse(
+				mcp.NewTextContent("No highlighted bookmarks found."),
+			), nil
+		}
+
+		var formattedResults strings.Builder
+		for _, bookmark := range bookmarks {
+			formattedResults.WriteString(fmt.Sprintf("\nID: %d\nTitle: %s\nURL: %s\nHighlights: %d\n---", bookmark.ID, bookmark.Title, bookmark.Link, bookmark.Highlights))
+		}
+
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(fmt.Sprintf("Found %d highlights in %d bookmarks:%s", total, len(bookmarks), formattedResults.String())),
+		), nil
+	}
+}
+
+// maxHighlightsMarkdownBytes caps the size of an export-highlights-markdown document.
+const maxHighlightsMarkdownBytes = 200 << 10
+
+// renderHighlightsMarkdown renders the highlights of bookmarks as Markdown, one
+// section per bookmark headed by its title and link, with each highlight as a
+// list item and its note as a blockquote below it. Bookmarks without highlights
+// are skipped. Sections that would take the document past maxBytes are left
+// out and counted in omitted.
+func renderHighlightsMarkdown(items []map[string]interface{}, maxBytes int) (doc string, omitted int) {
+	var out strings.Builder
+	out.WriteString("# Highlights\n")
+	for _, item := range items {
+		highlights := highlightsOf(item)
+		if len(highlights) == 0 {
+			continue
+		}
+
+		title := stringField(item, "title")
+		link := stringField(item, "link")
+		if title == "" {
+			title = link
+		}
+		var section strings.Builder
+		section.WriteString(fmt.Sprintf("\n## [%s](%s)\n\n", markdownEscaper.Replace(title), strings.ReplaceAll(link, ")", "%29")))
+		for _, highlight := range highlights {
+			text := strings.Join(strings.Fields(stringField(highlight, "text")), " ")
+			section.WriteString("- " + markdownEscaper.Replace(text) + "\n")
+			if note := strings.Join(strings.Fields(stringField(highlight, "note")), " "); note != "" {
+				section.WriteString("\n  > " + markdownEscaper.Replace(note) + "\n\n")
+			}
+		}
+
+		if omitted > 0 || out.Len()+section.Len() > maxBytes {
+			omitted++
+			continue
+		}
+		out.WriteString(section.String())
+	}
+	if omitted > 0 {
+		out.WriteString(fmt.Sprintf("\n_%d more highlighted bookmarks omitted to keep the document under %d KB._\n", omitted, maxBytes>>10))
+	}
+	return out.String(), omitted
+}
+
+type ExportHighlightsMarkdownArgs struct {
+	Collection int `json:"collection" jsonschema:"description=Collection ID to export (0 for all bookmarks)"`
+}
+
+func exportHighlightsMarkdownHandler(client *RaindropClient) func(ctx context.Context, args ExportHighlightsMarkdownArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args ExportHighlightsMarkdownArgs) (*mcp.ToolResponse, error) {
+		ctx, cancel := client.bulkContext(ctx)
+		defer cancel()
+
+		items, err := client.fetchHighlighted(ctx, args.Collection)
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+
+		if len(items) == 0 {
+			return mcp.NewToolResponse(
+				mcp.NewTextContent("No highlighted bookmarks found."),
+			), nil
+		}
+
+		doc, _ := renderHighlightsMarkdown(items, maxHighlightsMarkdownBytes)
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(doc),
+		), nil
+	}
+}
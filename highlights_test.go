@@ -0,0 +1,48 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHighlightsOf(t *testing.T) {
+	bookmark := map[string]interface{}{
+		"highlights": []interface{}{
+			map[string]interface{}{"text": "first"},
+			"malformed",
+			map[string]interface{}{"text": "second", "note": "why"},
+		},
+	}
+	highlights := highlightsOf(bookmark)
+	if len(highlights) != 2 || highlights[1]["text"] != "second" {
+		t.Errorf("Unexpected highlights: %v", highlights)
+	}
+
+	if got := highlightsOf(map[string]interface{}{}); len(got) != 0 {
+		t.Errorf("Expected no highlights, got %v", got)
+	}
+}
+
+func TestRenderHighlightsMarkdown(t *testing.T) {
+	items := []map[string]interface{}{
+		{"title": "Go *tips*", "link": "https://example.com/a", "highlights": []interface{}{
+			map[string]interface{}{"text": "Use   [gofmt]", "note": "always_"},
+			map[string]interface{}{"text": "Keep it simple"},
+		}},
+		{"title": "Nothing here", "link": "https://example.com/b"},
+	}
+
+	doc, omitted := renderHighlightsMarkdown(items, maxHighlightsMarkdownBytes)
+	expected := "# Highlights\n\n## [Go \\*tips\\*](https://example.com/a)\n\n- Use \\[gofmt\\]\n\n  > always\\_\n\n- Keep it simple\n"
+	if doc != expected || omitted != 0 {
+		t.Errorf("Unexpected document (%d omitted):\n%s", omitted, doc)
+	}
+	if strings.Contains(doc, "Nothing here") {
+		t.Error("Expected bookmarks without highlights to be skipped")
+	}
+
+	doc, omitted = renderHighlightsMarkdown(append(items, items[0], items[0]), len(doc))
+	if omitted != 2 || !strings.Contains(doc, "2 more highlighted bookmarks omitted") {
+		t.Errorf("Expected two sections to be omitted, got %d:\n%s", omitted, doc)
+	}
+}
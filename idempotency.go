@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"time"
+)
+
+// DefaultIdempotencyTTL is how long create-bookmark remembers an idempotency key.
+const DefaultIdempotencyTTL = 10 * time.Minute
+
+// idempotentResult is what a replayed create-bookmark call returns.
+type idempotentResult struct {
+	ID       int
+	Response string
+}
+
+// idempotentEntry is the state of one idempotency key. done is closed once the
+// create holding the key has finished; until then result and expires are unset.
+type idempotentEntry struct {
+	result  idempotentResult
+	expires time.Time
+	done    chan struct{}
+}
+
+// finished reports whether the create holding the entry's key has completed.
+func (e *idempotentEntry) finished() bool {
+	select {
+	case <-e.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// beginCreate claims key for a create. If an earlier create with the key
+// succeeded less than IdempotencyTTL ago, its result is returned with replay
+// set. If one is still in flight, beginCreate waits for it and then decides
+// again, so a retry arriving mid-create never sends a second request. Otherwise
+// the caller now holds the key and must call finishCreate. Keys live in process
+// memory only and are lost when the server restarts.
+func (r *RaindropClient) beginCreate(ctx context.Context, key string, now time.Time) (previous idempotentResult, replay bool, err error) {
+	for {
+		r.idempotencyMu.Lock()
+		if r.idempotency == nil {
+			r.idempotency = map[string]*idempotentEntry{}
+		}
+		for k, entry := range r.idempotency {
+			if entry.finished() && !now.Before(entry.expires) {
+				delete(r.idempotency, k)
+			}
+		}
+
+		entry, ok := r.idempotency[key]
+		if !ok {
+			r.idempotency[key] = &idempotentEntry{done: make(chan struct{})}
+			r.idempotencyMu.Unlock()
+			return idempotentResult{}, false, nil
+		}
+		r.idempotencyMu.Unlock()
+
+		if entry.finished() {
+			return entry.result, true, nil
+		}
+		select {
+		case <-entry.done:
+		case <-ctx.Done():
+			return idempotentResult{}, false, ctx.Err()
+		}
+	}
+}
+
+// finishCreate completes the create holding key. A non-nil result is
+// remembered for IdempotencyTTL; nil, for a failed create, releases the key so
+// a retry can try again. Callers waiting on the key are woken either way.
+func (r *RaindropClient) finishCreate(key string, result *idempotentResult, now time.Time) {
+	ttl := r.IdempotencyTTL
+	if ttl <= 0 {
+		ttl = DefaultIdempotencyTTL
+	}
+
+	r.idempotencyMu.Lock()
+	defer r.idempotencyMu.Unlock()
+
+	entry, ok := r.idempotency[key]
+	if !ok || entry.finished() {
+		return
+	}
+	if result == nil {
+		delete(r.idempotency, key)
+	} else {
+		entry.result = *result
+		entry.expires = now.Add(ttl)
+	}
+	close(entry.done)
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestIdempotencyKeys(t *testing.T) {
+	client := &RaindropClient{IdempotencyTTL: time.Minute}
+	ctx := context.Background()
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	if _, replay, err := client.beginCreate(ctx, "key", now); replay || err != nil {
+		t.Fatalf("Expected unknown key to be claimed, got %v, %v", replay, err)
+	}
+	client.finishCreate("key", &idempotentResult{ID: 42, Response: "created"}, now)
+
+	result, replay, err := client.beginCreate(ctx, "key", now.Add(30*time.Second))
+	if !replay || err != nil || result.ID != 42 || result.Response != "created" {
+		t.Errorf("Expected stored result, got %+v, %v, %v", result, replay, err)
+	}
+
+	if _, replay, _ := client.beginCreate(ctx, "key", now.Add(time.Minute)); replay {
+		t.Error("Expected key to expire after the TTL")
+	}
+	client.finishCreate("key", nil, now.Add(time.Minute))
+	if _, ok := client.idempotency["key"]; ok {
+		t.Error("Expected a failed create to release the key")
+	}
+
+	client.beginCreate(ctx, "old", now)
+	client.finishCreate("old", &idempotentResult{ID: 1}, now)
+	client.beginCreate(ctx, "other", now.Add(2*time.Minute))
+	if _, ok := client.idempotency["old"]; ok {
+		t.Error("Expected expired key to be pruned")
+	}
+}
+
+func TestBeginCreateWaitsForInFlightCreate(t *testing.T) {
+	client := &RaindropClient{}
+	ctx := context.Background()
+	client.beginCreate(ctx, "key", time.Now())
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, _, err := client.beginCreate(cancelled, "key", time.Now()); err == nil {
+		t.Error("Expected a cancelled wait to return the context error")
+	}
+
+	// A failed create hands the key to the next caller instead of replaying.
+	claimed := make(chan bool)
+	go func() {
+		_, replay, err := client.beginCreate(ctx, "key", time.Now())
+		claimed <- err == nil && !replay
+	}()
+	client.finishCreate("key", nil, time.Now())
+	if !<-claimed {
+		t.Error("Expected the waiting caller to claim the released key")
+	}
+}
+
+func TestCreateBookmarkIdempotencyUnderConcurrentRetries(t *testing.T) {
+	var posts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := posts.Add(1)
+		time.Sleep(100 * time.Millisecond)
+		fmt.Fprintf(w, `{"result": true, "item": {"_id": %d, "link": "https://example.com"}}`, id)
+	}))
+	defer server.Close()
+
+	client := &RaindropClient{Token: "test-token", BaseURL: server.URL}
+	handler := createBookmarkHandler(client)
+	args := CreateBookmarkArgs{URL: "https://example.com", Title: "Example", IdempotencyKey: "retry-1"}
+
+	var wg sync.WaitGroup
+	texts := make([]string, 2)
+	for i := range texts {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			resp, err := handler(context.Background(), args)
+			if err != nil {
+				t.Errorf("Unexpected error: %v", err)
+				return
+			}
+			texts[i] = resp.Content[0].TextContent.Text
+		}(i)
+	}
+	wg.Wait()
+
+	if n := posts.Load(); n != 1 {
+		t.Errorf("Expected one create request, got %d", n)
+	}
+	replays := 0
+	for _, text := range texts {
+		if strings.HasSuffix(text, "(already created with this idempotency key)") {
+			replays++
+		}
+	}
+	if replays != 1 {
+		t.Errorf("Expected exactly one replayed response, got %q", texts)
+	}
+}
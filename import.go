@@ -0,0 +1,309 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	mcp "github.com/metoro-io/mcp-golang"
+)
+
+// raindropBatchSize is the largest number of raindrops POST /raindrops accepts at once.
+const raindropBatchSize = 100
+
+type ImportURLsArgs struct {
+	BulkOptions
+	URLs       []string `json:"urls" jsonschema:"required,description=URLs to bookmark"`
+	Collection int      `json:"collection,omitempty" jsonschema:"description=Collection ID for all bookmarks"`
+	Tags       []string `json:"tags,omitempty" jsonschema:"description=Tags applied to every bookmark"`
+	JSON       bool     `json:"json,omitempty" jsonschema:"description=Return the result as JSON"`
+}
+
+// createRaindrops creates the given raindrops through the batch endpoint. It
+// returns the created raindrops and their outcome per item: the IDs of created
+// raindrops succeed, and every item of a failed batch fails with that batch's
+// error, named by its link. With stopOnError, the batches after the first
+// failure are not sent and their items fail with errBulkStopped.
+func (r *RaindropClient) createRaindrops(ctx context.Context, items []map[string]interface{}, stopOnError bool) ([]map[string]interface{}, BulkResult) {
+	var created []map[string]interface{}
+	result := newBulkResult(nil, nil)
+	failBatch := func(batch []map[string]interface{}, err error) {
+		for _, item := range batch {
+			result.Failed = append(result.Failed, BulkFailure{Item: stringField(item, "link"), Err: err.Error()})
+		}
+	}
+
+	for start := 0; start < len(items); start += raindropBatchSize {
+		end := min(start+raindropBatchSize, len(items))
+
+		response, err := r.MakeRequest(ctx, "/raindrops", "POST", map[string]interface{}{"items": items[start:end]})
+		if err != nil {
+			failBatch(items[start:end], err)
+			if stopOnError {
+				failBatch(items[end:], errBulkStopped)
+				break
+			}
+			continue
+		}
+
+		responseItems, _ := response["items"].([]interface{})
+		for _, item := range responseItems {
+			if bookmark, ok := item.(map[string]interface{}); ok {
+				created = append(created, bookmark)
+				result.Succeeded = append(result.Succeeded, intField(bookmark, "_id"))
+			}
+		}
+	}
+
+	return created, result
+}
+
+func importURLsHandler(client *RaindropClient) func(ctx context.Context, args ImportURLsArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args ImportURLsArgs) (*mcp.ToolResponse, error) {
+		urls := dedupeStrings(args.URLs)
+		if len(urls) == 0 {
+			return nil, fmt.Errorf("at least one URL is required")
+		}
+
+		var invalid []BulkFailure
+		var items []map[string]interface{}
+		for _, link := range urls {
+			if err := validateURL(link); err != nil {
+				invalid = append(invalid, BulkFailure{Item: link, Err: err.Error()})
+				continue
+			}
+			items = append(items, map[string]interface{}{
+				"link":       link,
+				"tags":       args.Tags,
+				"collection": map[string]interface{}{"$id": args.Collection},
+			})
+		}
+
+		ctx, cancel := client.bulkContext(ctx)
+		defer cancel()
+
+		created, result := client.createRaindrops(ctx, items, args.StopOnError)
+		result.Failed = append(invalid, result.Failed...)
+
+		if args.JSON {
+			return jsonResponse(result)
+		}
+
+		var report strings.Builder
+		for _, bookmark := range created {
+			report.WriteString(fmt.Sprintf("\nSaved: %s (ID %d)", stringField(bookmark, "link"), intField(bookmark, "_id")))
+		}
+		report.WriteString(result.FailedText())
+
+		responseText := fmt.Sprintf("Imported %d of %d URLs (%d failed):%s",
+			len(created), len(urls), len(urls)-len(created), report.String())
+
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(responseText),
+		), nil
+	}
+}
+
+type ImportJSONArgs struct {
+	JSON             string `json:"json" jsonschema:"required,description=Backup produced by export-json, or a JSON array of raindrops"`
+	Collection       int    `json:"collection,omitempty" jsonschema:"description=Collection ID to restore the bookmarks into"`
+	PreserveMetadata *bool  `json:"preserveMetadata,omitempty" jsonschema:"description=Keep tags, notes and created dates from the backup (default true)"`
+}
+
+// parseBackup returns the raindrop entries of an export-json document or of a
+// plain JSON array of raindrops.
+func parseBackup(data string) ([]interface{}, error) {
+	var decoded interface{}
+	if err := json.Unmarshal([]byte(data), &decoded); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %v", err)
+	}
+
+	switch v := decoded.(type) {
+	case []interface{}:
+		return v, nil
+	case map[string]interface{}:
+		if items, ok := v["items"].([]interface{}); ok {
+			return items, nil
+		}
+	}
+	return nil, fmt.Errorf("expected a JSON array of raindrops or an export-json backup with an items array")
+}
+
+// restoreItem builds the create payload for one backup entry.
+func restoreItem(entry interface{}, collection int, preserveMetadata bool) (map[string]interface{}, error) {
+	bookmark, ok := entry.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("entry is not an object")
+	}
+	link := stringField(bookmark, "link")
+	if err := validateURL(link); err != nil {
+		return nil, err
+	}
+
+	item := map[string]interface{}{
+		"link":       link,
+		"collection": map[string]interface{}{"$id": collection},
+	}
+	for _, field := range []string{"title", "excerpt", "type"} {
+		if value := stringField(bookmark, field); value != "" {
+			item[field] = value
+		}
+	}
+
+	if preserveMetadata {
+		if tags := extractTags(bookmark); len(tags) > 0 {
+			item["tags"] = tags
+		}
+		if note := stringField(bookmark, "note"); note != "" {
+			item["note"] = note
+		}
+		if value := stringField(bookmark, "created"); value != "" {
+			created, err := parseDate(value)
+			if err != nil {
+				return nil, err
+			}
+			item["created"] = created.Format(time.RFC3339)
+		}
+	}
+	return item, nil
+}
+
+func importJSONHandler(client *RaindropClient) func(ctx context.Context, args ImportJSONArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args ImportJSONArgs) (*mcp.ToolResponse, error) {
+		entries, err := parseBackup(args.JSON)
+		if err != nil {
+			return nil, err
+		}
+
+		preserveMetadata := true
+		if args.PreserveMetadata != nil {
+			preserveMetadata = *args.PreserveMetadata
+		}
+
+		var report strings.Builder
+		var items []map[string]interface{}
+		for i, entry := range entries {
+			item, err := restoreItem(entry, args.Collection, preserveMetadata)
+			if err != nil {
+				report.WriteString(fmt.Sprintf("\nSkipped entry %d: %v", i, err))
+				continue
+			}
+			items = append(items, item)
+		}
+		skipped := len(entries) - len(items)
+
+		ctx, cancel := client.bulkContext(ctx)
+		defer cancel()
+
+		created, result := client.createRaindrops(ctx, items, false)
+		report.WriteString(result.FailedText())
+
+		responseText := fmt.Sprintf("Restored %d of %d bookmarks (%d skipped as malformed):%s",
+			len(created), len(entries), skipped, report.String())
+
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(responseText),
+		), nil
+	}
+}
+
+// urlPattern finds http(s) URLs in free text.
+var urlPattern = regexp.MustCompile(`https?://[^\s<>"'` + "`" + `]+`)
+
+// urlClosers maps the closing brackets trimURL may drop to their openers.
+var urlClosers = map[byte]byte{')': '(', ']': '[', '}': '{'}
+
+// trimURL drops the punctuation that ends a sentence rather than the URL found
+// in it. A closing bracket is dropped only when it has no opener in the URL, so
+// links such as https://en.wikipedia.org/wiki/Go_(programming_language) keep
+// theirs.
+func trimURL(link string) string {
+	for link != "" {
+		last := link[len(link)-1]
+		if strings.IndexByte(".,;:!?", last) >= 0 {
+			link = link[:len(link)-1]
+			continue
+		}
+		opener, ok := urlClosers[last]
+		if !ok || strings.Count(link, string(last)) <= strings.Count(link, string(opener)) {
+			break
+		}
+		link = link[:len(link)-1]
+	}
+	return link
+}
+
+// quickSaveFields splits pasted text into its first URL and the surrounding
+// text: the first remaining line becomes the title, the rest the excerpt.
+func quickSaveFields(text string) (link, title, excerpt string, err error) {
+	loc := urlPattern.FindStringIndex(text)
+	if loc == nil {
+		return "", "", "", fmt.Errorf("no URL found in the text")
+	}
+	link = trimURL(text[loc[0]:loc[1]])
+	rest := text[:loc[0]] + text[loc[1]:]
+
+	var lines []string
+	for _, line := range strings.Split(rest, "\n") {
+		if line = strings.Join(strings.Fields(line), " "); strings.Trim(line, "-–—:|") != "" {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) > 0 {
+		title = strings.Trim(lines[0], " -–—:|")
+		excerpt = strings.Join(lines[1:], " ")
+	}
+	return link, title, excerpt, nil
+}
+
+type QuickSaveArgs struct {
+	Text       string `json:"text" jsonschema:"required,description=Pasted text containing a link"`
+	Collection int    `json:"collection,omitempty" jsonschema:"description=Collection ID"`
+}
+
+func quickSaveHandler(client *RaindropClient) func(ctx context.Context, args QuickSaveArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args QuickSaveArgs) (*mcp.ToolResponse, error) {
+		link, title, excerpt, err := quickSaveFields(args.Text)
+		if err != nil {
+			return nil, err
+		}
+		if err := validateURL(link); err != nil {
+			return nil, err
+		}
+
+		body := map[string]interface{}{
+			"link":       link,
+			"tags":       mergeTags(client.DefaultTags),
+			"collection": map[string]interface{}{"$id": args.Collection},
+		}
+		if title != "" {
+			body["title"] = title
+		}
+		if excerpt != "" {
+			body["excerpt"] = excerpt
+		}
+		if wantsParse(title, nil) {
+			body["pleaseParse"] = map[string]interface{}{}
+		}
+
+		result, err := client.MakeRequest(ctx, "/raindrop", "POST", body)
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+		item, _ := result["item"].(map[string]interface{})
+
+		if title == "" {
+			title = "(left to Raindrop)"
+		}
+		if excerpt == "" {
+			excerpt = "(none)"
+		}
+
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(fmt.Sprintf("Saved bookmark %d:\nURL: %s\nTitle: %s\nExcerpt: %s", intField(item, "_id"), link, title, excerpt)),
+		), nil
+	}
+}
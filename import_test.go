@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestParseBackup(t *testing.T) {
+	for _, input := range []string{
+		`[{"link": "https://example.com"}]`,
+		`{"exportedAt": "2024-05-01T10:00:00Z", "count": 1, "items": [{"link": "https://example.com"}]}`,
+	} {
+		entries, err := parseBackup(input)
+		if err != nil || len(entries) != 1 {
+			t.Errorf("parseBackup(%s) = %v, %v", input, entries, err)
+		}
+	}
+
+	for _, input := range []string{`not json`, `{"items": "nope"}`, `"text"`} {
+		if _, err := parseBackup(input); err == nil {
+			t.Errorf("Expected error for %s", input)
+		}
+	}
+}
+
+func TestRestoreItem(t *testing.T) {
+	entry := map[string]interface{}{
+		"_id":     float64(7),
+		"link":    "https://example.com",
+		"title":   "Example",
+		"tags":    []interface{}{"go"},
+		"note":    "read later",
+		"created": "2021-03-04T05:06:07.000Z",
+	}
+
+	item, err := restoreItem(entry, 12, true)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := map[string]interface{}{
+		"link":       "https://example.com",
+		"title":      "Example",
+		"collection": map[string]interface{}{"$id": 12},
+		"tags":       []string{"go"},
+		"note":       "read later",
+		"created":    "2021-03-04T05:06:07Z",
+	}
+	if !reflect.DeepEqual(item, expected) {
+		t.Errorf("Expected %v, got %v", expected, item)
+	}
+
+	item, err = restoreItem(entry, 0, false)
+	if err != nil || item["tags"] != nil || item["note"] != nil || item["created"] != nil {
+		t.Errorf("Expected metadata to be dropped, got %v, %v", item, err)
+	}
+
+	for _, bad := range []interface{}{"https://example.com", map[string]interface{}{"link": "ftp://example.com"}} {
+		if _, err := restoreItem(bad, 0, true); err == nil {
+			t.Errorf("Expected error for %v", bad)
+		}
+	}
+}
+
+func TestQuickSaveFields(t *testing.T) {
+	text := "Great read on Go generics:\nhttps://go.dev/blog/intro-generics.\nExplains type parameters\nwith examples"
+	link, title, excerpt, err := quickSaveFields(text)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if link != "https://go.dev/blog/intro-generics" || title != "Great read on Go generics" || excerpt != "Explains type parameters with examples" {
+		t.Errorf("Unexpected fields: %q, %q, %q", link, title, excerpt)
+	}
+
+	link, title, excerpt, err = quickSaveFields("(see https://example.com/a?b=1)")
+	if err != nil || link != "https://example.com/a?b=1" || title != "(see" || excerpt != "" {
+		t.Errorf("Unexpected fields: %q, %q, %q, %v", link, title, excerpt, err)
+	}
+
+	link, _, _, err = quickSaveFields("Go: https://en.wikipedia.org/wiki/Go_(programming_language).")
+	if err != nil || link != "https://en.wikipedia.org/wiki/Go_(programming_language)" {
+		t.Errorf("Expected the balanced parenthesis to stay, got %q, %v", link, err)
+	}
+
+	link, _, _, err = quickSaveFields("(about Go: https://en.wikipedia.org/wiki/Go_(programming_language))")
+	if err != nil || link != "https://en.wikipedia.org/wiki/Go_(programming_language)" {
+		t.Errorf("Expected only the unbalanced parenthesis to go, got %q, %v", link, err)
+	}
+
+	if _, _, _, err := quickSaveFields("no links here"); err == nil {
+		t.Error("Expected error when the text has no URL")
+	}
+}
+
+func TestQuickSaveParsesUntitledLinks(t *testing.T) {
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body = nil
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Write([]byte(`{"item": {"_id": 1}}`))
+	}))
+	defer server.Close()
+
+	client := &RaindropClient{Token: "test-token", BaseURL: server.URL}
+	if _, err := quickSaveHandler(client)(context.Background(), QuickSaveArgs{Text: "https://example.com/post"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, ok := body["pleaseParse"]; !ok {
+		t.Errorf("Expected an untitled link to be parsed by Raindrop, got %v", body)
+	}
+
+	if _, err := quickSaveHandler(client)(context.Background(), QuickSaveArgs{Text: "A title\nhttps://example.com/post"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, ok := body["pleaseParse"]; ok || body["title"] != "A title" {
+		t.Errorf("Expected the given title to be kept without parsing, got %v", body)
+	}
+}
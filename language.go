@@ -0,0 +1,212 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode"
+
+	mcp "github.com/metoro-io/mcp-golang"
+)
+
+// languageTagPrefix starts the tags tag-by-language adds, e.g. lang:en.
+const languageTagPrefix = "lang:"
+
+// minLanguageWords is the fewest words a Latin-script text needs before its
+// language is guessed from stopwords.
+const minLanguageWords = 4
+
+// scriptLanguages maps scripts whose text is almost always in one language to
+// that language's code. Han is handled separately, since Japanese text mixes it
+// with kana.
+var scriptLanguages = []struct {
+	script *unicode.RangeTable
+	code   string
+}{
+	{unicode.Hangul, "ko"},
+	{unicode.Hiragana, "ja"},
+	{unicode.Katakana, "ja"},
+	{unicode.Thai, "th"},
+	{unicode.Greek, "el"},
+	{unicode.Hebrew, "he"},
+}
+
+// sharedScripts are written by several common languages (Cyrillic by Russian,
+// Ukrainian, Bulgarian and Serbian; Arabic by Arabic, Persian and Urdu;
+// Devanagari by Hindi, Marathi and Nepali), so text mostly in them is left
+// unclassified rather than tagged with a guess.
+var sharedScripts = []*unicode.RangeTable{unicode.Cyrillic, unicode.Arabic, unicode.Devanagari}
+
+// languageStopwords are very common words that identify Latin-script languages.
+var languageStopwords = map[string][]string{
+	"en": {"the", "and", "of", "to", "in", "is", "for", "with", "on", "that", "how", "what", "your", "you", "are", "this", "from"},
+	"de": {"der", "die", "das", "und", "ist", "nicht", "mit", "für", "ein", "eine", "auf", "den", "zu", "wie", "sich", "von"},
+	"fr": {"le", "la", "les", "et", "est", "une", "pour", "des", "du", "dans", "que", "pas", "sur", "avec", "comment", "au"},
+	"es": {"el", "la", "los", "las", "y", "es", "una", "para", "del", "que", "con", "por", "en", "cómo", "qué", "se"},
+	"it": {"il", "di", "che", "è", "per", "una", "della", "con", "non", "sono", "come", "gli", "del", "nel", "alla"},
+	"pt": {"o", "os", "e", "é", "uma", "para", "do", "da", "dos", "que", "com", "não", "em", "como", "por", "ao"},
+	"nl": {"de", "het", "een", "en", "is", "van", "voor", "niet", "met", "op", "dat", "zijn", "hoe", "wat", "je"},
+}
+
+// detectLanguage guesses the ISO 639-1 code of text. ok is false when there is
+// too little text or no language stands out.
+func detectLanguage(text string) (code string, ok bool) {
+	scripts := map[string]int{}
+	han, shared, letters := 0, 0, 0
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		if unicode.Is(unicode.Han, r) {
+			han++
+			continue
+		}
+		if unicode.IsOneOf(sharedScripts, r) {
+			shared++
+			continue
+		}
+		for _, s := range scriptLanguages {
+			if unicode.Is(s.script, r) {
+				scripts[s.code]++
+				break
+			}
+		}
+	}
+	if letters == 0 || shared*2 > letters {
+		return "", false
+	}
+
+	// Any kana makes Han text Japanese; Han alone is Chinese.
+	if scripts["ja"] > 0 {
+		scripts["ja"] += han
+	} else {
+		scripts["zh"] = han
+	}
+	best, bestCount := "", 0
+	for lang, count := range scripts {
+		if count > bestCount || (count == bestCount && lang < best) {
+			best, bestCount = lang, count
+		}
+	}
+	if bestCount*2 > letters {
+		return best, bestCount >= 2
+	}
+
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '\''
+	})
+	if len(words) < minLanguageWords {
+		return "", false
+	}
+	scores := map[string]int{}
+	for _, word := range words {
+		for lang, stopwords := range languageStopwords {
+			for _, stopword := range stopwords {
+				if word == stopword {
+					scores[lang]++
+					break
+				}
+			}
+		}
+	}
+
+	best, bestScore, second := "", 0, 0
+	for lang, score := range scores {
+		switch {
+		case score > bestScore:
+			best, bestScore, second = lang, score, bestScore
+		case score > second:
+			second = score
+		}
+	}
+	if bestScore < 2 || bestScore == second {
+		return "", false
+	}
+	return best, true
+}
+
+// languageTagOf returns the lang: tag a bookmark already carries, if any.
+func languageTagOf(bookmark map[string]interface{}) (string, bool) {
+	for _, tag := range extractTags(bookmark) {
+		if strings.HasPrefix(strings.ToLower(tag), languageTagPrefix) {
+			return tag, true
+		}
+	}
+	return "", false
+}
+
+type TagByLanguageArgs struct {
+	BulkOptions
+	Collection int  `json:"collection" jsonschema:"description=Collection ID to tag (0 for all bookmarks)"`
+	DryRun     bool `json:"dryRun,omitempty" jsonschema:"description=Report the planned tags without changing anything"`
+}
+
+func tagByLanguageHandler(client *RaindropClient) func(ctx context.Context, args TagByLanguageArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args TagByLanguageArgs) (*mcp.ToolResponse, error) {
+		ctx, cancel := client.bulkContext(ctx)
+		defer cancel()
+
+		items, err := client.listRaindrops(ctx, args.Collection, url.Values{}, maxExportItems)
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+
+		var ids []int
+		targets := map[int]map[string]interface{}{}
+		planned := map[int]string{}
+		alreadyTagged, unclassified := 0, 0
+		for _, item := range items {
+			if _, ok := languageTagOf(item); ok {
+				alreadyTagged++
+				continue
+			}
+			code, ok := detectLanguage(stringField(item, "title") + "\n" + stringField(item, "excerpt"))
+			if !ok {
+				unclassified++
+				continue
+			}
+			id := intField(item, "_id")
+			ids = append(ids, id)
+			targets[id] = item
+			planned[id] = languageTagPrefix + code
+		}
+
+		result := newBulkResult(ids, make([]error, len(ids)))
+		if !args.DryRun {
+			result = client.bulkApply(ctx, ids, args.StopOnError, func(ctx context.Context, id int) error {
+				tags := mergeTags(extractTags(targets[id]), []string{planned[id]})
+				_, err := client.updateRaindrop(ctx, id, map[string]interface{}{"tags": tags})
+				return err
+			})
+		}
+
+		totals := map[string]int{}
+		for _, id := range result.Succeeded {
+			totals[planned[id]]++
+		}
+		summary := make([]tagCount, 0, len(totals))
+		for tag, count := range totals {
+			summary = append(summary, tagCount{Tag: tag, Count: count})
+		}
+		sortTagCounts(summary)
+
+		verb := "Tagged"
+		if args.DryRun {
+			verb = "Would tag"
+		}
+
+		var report strings.Builder
+		report.WriteString(fmt.Sprintf("%s %d of %d bookmarks by language:", verb, len(result.Succeeded), len(items)))
+		for _, tag := range summary {
+			report.WriteString(fmt.Sprintf("\n%s: %d", tag.Tag, tag.Count))
+		}
+		report.WriteString(fmt.Sprintf("\nAlready tagged: %d\nLanguage unclear: %d", alreadyTagged, unclassified))
+		report.WriteString(result.FailedText())
+
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(report.String()),
+		), nil
+	}
+}
@@ -0,0 +1,43 @@
+package main
+
+import "testing"
+
+func TestDetectLanguage(t *testing.T) {
+	tests := map[string]string{
+		"How to write tests for your Go code and what to avoid":       "en",
+		"Wie man mit der neuen API und den Daten umgeht":              "de",
+		"Comment installer le serveur et les outils pour la maison":   "fr",
+		"Cómo configurar el servidor para los usuarios y las pruebas": "es",
+		"Hoe je een website maakt voor het team en de klanten":        "nl",
+		"Go 언어로 웹 서버 만들기":                                             "ko",
+		"東京でおすすめのラーメン屋":                                               "ja",
+		"如何学习编程语言":                                                    "zh",
+	}
+	for text, expected := range tests {
+		if got, ok := detectLanguage(text); !ok || got != expected {
+			t.Errorf("detectLanguage(%q) = %q, %v, expected %q", text, got, ok, expected)
+		}
+	}
+
+	// Cyrillic, Arabic and Devanagari are each written by several languages.
+	for _, text := range []string{"Как работает сборщик мусора", "Як працює збирач сміття", "چگونه برنامه نویسی یاد بگیریم", "प्रोग्रामिंग कशी शिकायची"} {
+		if got, ok := detectLanguage(text); ok {
+			t.Errorf("Expected %q to be left unclassified, got %q", text, got)
+		}
+	}
+
+	for _, text := range []string{"", "Kubernetes", "GitHub - golang/go", "12345 !!!"} {
+		if got, ok := detectLanguage(text); ok {
+			t.Errorf("Expected %q to be too short to classify, got %q", text, got)
+		}
+	}
+}
+
+func TestLanguageTagOf(t *testing.T) {
+	if tag, ok := languageTagOf(map[string]interface{}{"tags": []interface{}{"go", "Lang:EN"}}); !ok || tag != "Lang:EN" {
+		t.Errorf("Expected existing language tag, got %q, %v", tag, ok)
+	}
+	if _, ok := languageTagOf(map[string]interface{}{"tags": []interface{}{"language"}}); ok {
+		t.Error("Expected no language tag")
+	}
+}
@@ -0,0 +1,369 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	mcp "github.com/metoro-io/mcp-golang"
+)
+
+// redirectTimeout bounds how long resolving a URL's redirects may take.
+const redirectTimeout = 10 * time.Second
+
+// defaultTrackingParams are query parameter prefixes removed by stripTrackingParams.
+var defaultTrackingParams = []string{
+	"utm_", "fbclid", "gclid", "dclid", "gbraid", "wbraid", "msclkid", "yclid",
+	"mc_cid", "mc_eid", "igshid", "_hsenc", "_hsmi", "mkt_tok", "oly_anon_id", "oly_enc_id", "vero_id",
+}
+
+// validateURL checks that raw is an absolute http(s) URL.
+func validateURL(raw string) error {
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("invalid URL %q: %v", raw, err)
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return fmt.Errorf("invalid URL %q: scheme must be http or https", raw)
+	}
+	if parsed.Host == "" {
+		return fmt.Errorf("invalid URL %q: missing host", raw)
+	}
+	return nil
+}
+
+// dedupeStrings trims the input and drops empty and repeated values, keeping order.
+func dedupeStrings(values []string) []string {
+	seen := map[string]bool{}
+	result := []string{}
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if v == "" || seen[v] {
+			continue
+		}
+		seen[v] = true
+		result = append(result, v)
+	}
+	return result
+}
+
+// resolveRedirects follows redirects from raw with a HEAD request and returns the
+// final URL. It falls back to raw if the request fails or times out.
+func resolveRedirects(raw string) string {
+	httpClient := &http.Client{Timeout: redirectTimeout}
+	resp, err := httpClient.Head(raw)
+	if err != nil {
+		log.Printf("Warning: unable to resolve redirects for %s: %v", raw, err)
+		return raw
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return raw
+	}
+	return resp.Request.URL.String()
+}
+
+// stripTrackingParams removes query parameters whose name starts with one of
+// prefixes (case-insensitively). Other parameters keep their original order and
+// encoding. Unparseable URLs are returned unchanged.
+func stripTrackingParams(raw string, prefixes []string) string {
+	parsed, err := url.Parse(raw)
+	if err != nil || parsed.RawQuery == "" {
+		return raw
+	}
+
+	var kept []string
+	for _, pair := range strings.Split(parsed.RawQuery, "&") {
+		key := pair
+		if i := strings.Index(pair, "="); i >= 0 {
+			key = pair[:i]
+		}
+		if name, err := url.QueryUnescape(key); err == nil {
+			key = name
+		}
+		if !isTrackingParam(key, prefixes) {
+			kept = append(kept, pair)
+		}
+	}
+
+	parsed.RawQuery = strings.Join(kept, "&")
+	return parsed.String()
+}
+
+// isTrackingParam reports whether name starts with one of prefixes.
+func isTrackingParam(name string, prefixes []string) bool {
+	name = strings.ToLower(name)
+	for _, prefix := range prefixes {
+		if prefix != "" && strings.HasPrefix(name, strings.ToLower(prefix)) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeURL returns a canonical form of raw for comparing bookmarks: the
+// scheme and host are lowercased, default ports, fragments and a trailing slash
+// are dropped. Unparseable URLs are returned trimmed but otherwise unchanged.
+func normalizeURL(raw string) string {
+	raw = strings.TrimSpace(raw)
+	parsed, err := url.Parse(raw)
+	if err != nil || parsed.Host == "" {
+		return raw
+	}
+
+	parsed.Scheme = strings.ToLower(parsed.Scheme)
+	host := strings.ToLower(parsed.Hostname())
+	if port := parsed.Port(); port != "" && !(parsed.Scheme == "http" && port == "80") && !(parsed.Scheme == "https" && port == "443") {
+		host += ":" + port
+	}
+	parsed.Host = host
+	parsed.Fragment = ""
+	parsed.RawFragment = ""
+	parsed.Path = strings.TrimRight(parsed.Path, "/")
+	parsed.RawPath = ""
+	return parsed.String()
+}
+
+// urlCheck is what check-url reports about a URL.
+type urlCheck struct {
+	Status      int
+	StatusText  string
+	FinalURL    string
+	ContentType string
+}
+
+// checkURL requests raw with HEAD, falling back to GET for servers that reject
+// HEAD, and follows redirects. It gives up after redirectTimeout.
+func checkURL(ctx context.Context, raw string) (urlCheck, error) {
+	ctx, cancel := context.WithTimeout(ctx, redirectTimeout)
+	defer cancel()
+
+	httpClient := &http.Client{}
+	var resp *http.Response
+	for _, method := range []string{"HEAD", "GET"} {
+		req, err := http.NewRequestWithContext(ctx, method, raw, nil)
+		if err != nil {
+			return urlCheck{}, err
+		}
+		resp, err = httpClient.Do(req)
+		if err != nil {
+			return urlCheck{}, err
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusMethodNotAllowed && resp.StatusCode != http.StatusNotImplemented {
+			break
+		}
+	}
+
+	return urlCheck{
+		Status:      resp.StatusCode,
+		StatusText:  http.StatusText(resp.StatusCode),
+		FinalURL:    resp.Request.URL.String(),
+		ContentType: resp.Header.Get("Content-Type"),
+	}, nil
+}
+
+// describeFetchError explains why a URL could not be fetched, calling out
+// certificate problems separately from other network errors.
+func describeFetchError(err error) string {
+	var certErr *tls.CertificateVerificationError
+	var unknownAuthority x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var invalidCert x509.CertificateInvalidError
+	switch {
+	case errors.As(err, &certErr), errors.As(err, &unknownAuthority), errors.As(err, &hostnameErr), errors.As(err, &invalidCert):
+		return fmt.Sprintf("TLS certificate error: %v", err)
+	case errors.Is(err, context.DeadlineExceeded):
+		return fmt.Sprintf("timed out after %s", redirectTimeout)
+	default:
+		return fmt.Sprintf("request failed: %v", err)
+	}
+}
+
+type CheckURLArgs struct {
+	URL string `json:"url" jsonschema:"required,description=URL to check"`
+}
+
+func checkURLHandler() func(ctx context.Context, args CheckURLArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args CheckURLArgs) (*mcp.ToolResponse, error) {
+		if err := validateURL(args.URL); err != nil {
+			return nil, err
+		}
+
+		check, err := checkURL(ctx, args.URL)
+		if err != nil {
+			return mcp.NewToolResponse(
+				mcp.NewTextContent(fmt.Sprintf("%s looks broken: %s", args.URL, describeFetchError(err))),
+			), nil
+		}
+
+		verdict := "reachable"
+		if check.Status >= 400 {
+			verdict = "broken"
+		}
+		contentType := check.ContentType
+		if contentType == "" {
+			contentType = "unknown"
+		}
+
+		responseText := fmt.Sprintf("%s is %s.\nStatus: %d %s\nFinal URL: %s\nContent type: %s",
+			args.URL, verdict, check.Status, check.StatusText, check.FinalURL, contentType)
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(responseText),
+		), nil
+	}
+}
+
+type PreviewURLArgs struct {
+	URL string `json:"url" jsonschema:"required,description=URL to preview"`
+}
+
+func previewURLHandler(client *RaindropClient) func(ctx context.Context, args PreviewURLArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args PreviewURLArgs) (*mcp.ToolResponse, error) {
+		if err := validateURL(args.URL); err != nil {
+			return nil, err
+		}
+
+		parsed, err := client.parseURL(ctx, args.URL)
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+
+		preview := map[string]string{
+			"Title":   stringField(parsed, "title"),
+			"Excerpt": strings.Join(strings.Fields(stringField(parsed, "excerpt")), " "),
+			"Cover":   parsedCover(parsed),
+			"Type":    stringField(parsed, "type"),
+		}
+
+		var report strings.Builder
+		report.WriteString(fmt.Sprintf("Preview of %s (not saved):", args.URL))
+		for _, field := range []string{"Title", "Excerpt", "Cover", "Type"} {
+			value := preview[field]
+			if value == "" {
+				value = "none"
+			}
+			report.WriteString(fmt.Sprintf("\n%s: %s", field, value))
+		}
+
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(report.String()),
+		), nil
+	}
+}
+
+// cleanURL returns raw with tracking parameters stripped, the scheme and host
+// lowercased, a default port and trailing slashes dropped. Unlike
+// normalizeURL, which is lossy and meant only for comparing bookmarks, it
+// keeps the path's escaping (%2F stays %2F) and the fragment. Unparseable URLs
+// are returned unchanged.
+//
+// safe reports whether the cleaned URL differs from raw only by case and
+// tracking parameters. Dropping a port or a trailing slash can change the page
+// a server returns (/docs/ and /docs may differ), so such changes are not safe.
+func cleanURL(raw string, trackingPrefixes []string) (clean string, safe bool) {
+	stripped := stripTrackingParams(strings.TrimSpace(raw), trackingPrefixes)
+	parsed, err := url.Parse(stripped)
+	if err != nil || parsed.Host == "" {
+		return raw, true
+	}
+
+	parsed.Scheme = strings.ToLower(parsed.Scheme)
+	parsed.Host = strings.ToLower(parsed.Host)
+	lowered := parsed.String()
+
+	host := parsed.Hostname()
+	if port := parsed.Port(); port != "" && !(parsed.Scheme == "http" && port == "80") && !(parsed.Scheme == "https" && port == "443") {
+		host += ":" + port
+	}
+	parsed.Host = host
+
+	escaped := parsed.EscapedPath()
+	if escaped != "/" {
+		escaped = strings.TrimRight(escaped, "/")
+	}
+	path, err := url.PathUnescape(escaped)
+	if err != nil {
+		return raw, true
+	}
+	parsed.Path, parsed.RawPath = path, escaped
+	clean = parsed.String()
+	return clean, clean == lowered
+}
+
+type NormalizeURLsArgs struct {
+	BulkOptions
+	Collection int  `json:"collection" jsonschema:"description=Collection ID to clean up (0 for all bookmarks)"`
+	Apply      bool `json:"apply,omitempty" jsonschema:"description=Update the bookmarks; without it only the planned changes are shown"`
+}
+
+func normalizeURLsHandler(client *RaindropClient) func(ctx context.Context, args NormalizeURLsArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args NormalizeURLsArgs) (*mcp.ToolResponse, error) {
+		ctx, cancel := client.bulkContext(ctx)
+		defer cancel()
+
+		items, err := client.listRaindrops(ctx, args.Collection, url.Values{}, maxExportItems)
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+
+		var ids, unsafe []int
+		links := map[int]string{}
+		cleaned := map[int]string{}
+		for _, item := range items {
+			link := stringField(item, "link")
+			clean, safe := cleanURL(link, client.TrackingParams)
+			if clean == link {
+				continue
+			}
+			id := intField(item, "_id")
+			if !safe {
+				unsafe = append(unsafe, id)
+				links[id] = link
+				cleaned[id] = clean
+				continue
+			}
+			ids = append(ids, id)
+			links[id] = link
+			cleaned[id] = clean
+		}
+
+		result := newBulkResult(ids, make([]error, len(ids)))
+		if args.Apply {
+			result = client.bulkApply(ctx, ids, args.StopOnError, func(ctx context.Context, id int) error {
+				_, err := client.updateRaindrop(ctx, id, map[string]interface{}{"link": cleaned[id]})
+				return err
+			})
+		}
+
+		verb := "Normalized"
+		if !args.Apply {
+			verb = "Would normalize"
+		}
+
+		var report strings.Builder
+		report.WriteString(fmt.Sprintf("%s %d of %d URLs:", verb, len(result.Succeeded), len(items)))
+		for _, id := range result.Succeeded {
+			report.WriteString(fmt.Sprintf("\nBookmark %d: %s -> %s", id, links[id], cleaned[id]))
+		}
+		report.WriteString(result.FailedText())
+		if len(unsafe) > 0 {
+			report.WriteString(fmt.Sprintf("\nSkipped %d that could change the page the link opens:", len(unsafe)))
+			for _, id := range unsafe {
+				report.WriteString(fmt.Sprintf("\nBookmark %d: %s -> %s", id, links[id], cleaned[id]))
+			}
+		}
+
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(report.String()),
+		), nil
+	}
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestValidateURL(t *testing.T) {
+	valid := []string{"https://example.com", "http://example.com/path?q=1"}
+	for _, raw := range valid {
+		if err := validateURL(raw); err != nil {
+			t.Errorf("Expected %q to be valid, got: %v", raw, err)
+		}
+	}
+
+	invalid := []string{"", "example.com", "ftp://example.com", "https://", "http://[::1"}
+	for _, raw := range invalid {
+		if err := validateURL(raw); err == nil {
+			t.Errorf("Expected %q to be invalid", raw)
+		}
+	}
+}
+
+func TestDedupeStrings(t *testing.T) {
+	got := dedupeStrings([]string{" a ", "b", "a", "", "c", "b"})
+	expected := []string{"a", "b", "c"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+}
+
+func TestResolveRedirects(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/short":
+			http.Redirect(w, r, "/final", http.StatusMovedPermanently)
+		case "/final":
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	if got := resolveRedirects(server.URL + "/short"); got != server.URL+"/final" {
+		t.Errorf("Expected redirect to resolve to %s/final, got %s", server.URL, got)
+	}
+	if got := resolveRedirects(server.URL + "/missing"); got != server.URL+"/missing" {
+		t.Errorf("Expected fallback to original URL, got %s", got)
+	}
+	if got := resolveRedirects("http://127.0.0.1:0/unreachable"); got != "http://127.0.0.1:0/unreachable" {
+		t.Errorf("Expected fallback to original URL, got %s", got)
+	}
+}
+
+func TestStripTrackingParams(t *testing.T) {
+	tests := map[string]string{
+		"https://example.com/a?utm_source=x&id=5&UTM_Medium=y":  "https://example.com/a?id=5",
+		"https://example.com/a?fbclid=abc":                      "https://example.com/a",
+		"https://example.com/a?q=go&page=2#section":             "https://example.com/a?q=go&page=2#section",
+		"https://example.com/a?gclid=1&v=dQw4w9WgXcQ&t=42#frag": "https://example.com/a?v=dQw4w9WgXcQ&t=42#frag",
+		"https://example.com/a":                                 "https://example.com/a",
+	}
+	for input, expected := range tests {
+		if got := stripTrackingParams(input, defaultTrackingParams); got != expected {
+			t.Errorf("stripTrackingParams(%q) = %q, expected %q", input, got, expected)
+		}
+	}
+
+	if got := stripTrackingParams("https://example.com/?ref=x&id=1", []string{"ref"}); got != "https://example.com/?id=1" {
+		t.Errorf("Expected custom prefix to be stripped, got %q", got)
+	}
+}
+
+func TestNormalizeURL(t *testing.T) {
+	tests := map[string]string{
+		"HTTPS://Example.COM/Path/":         "https://example.com/Path",
+		"https://example.com:443/a#section": "https://example.com/a",
+		"http://example.com:8080/a?q=1":     "http://example.com:8080/a?q=1",
+		"http://example.com:80/":            "http://example.com",
+		" https://example.com/a?b=2&a=1 ":   "https://example.com/a?b=2&a=1",
+		"not a url":                         "not a url",
+	}
+	for input, expected := range tests {
+		if got := normalizeURL(input); got != expected {
+			t.Errorf("normalizeURL(%q) = %q, expected %q", input, got, expected)
+		}
+	}
+}
+
+func TestCheckURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/short":
+			http.Redirect(w, r, "/final", http.StatusFound)
+		case "/final":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		case "/no-head":
+			if r.Method == "HEAD" {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+			w.Header().Set("Content-Type", "application/pdf")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	check, err := checkURL(context.Background(), server.URL+"/short")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := urlCheck{Status: 200, StatusText: "OK", FinalURL: server.URL + "/final", ContentType: "text/html; charset=utf-8"}
+	if check != expected {
+		t.Errorf("Expected %+v, got %+v", expected, check)
+	}
+
+	if check, err := checkURL(context.Background(), server.URL+"/no-head"); err != nil || check.ContentType != "application/pdf" {
+		t.Errorf("Expected GET fallback, got %+v, %v", check, err)
+	}
+	if check, err := checkURL(context.Background(), server.URL+"/missing"); err != nil || check.Status != 404 {
+		t.Errorf("Expected 404, got %+v, %v", check, err)
+	}
+	if _, err := checkURL(context.Background(), "http://127.0.0.1:0/unreachable"); err == nil {
+		t.Error("Expected error for unreachable URL")
+	}
+}
+
+func TestDescribeFetchErrorTLS(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	_, err := checkURL(context.Background(), server.URL)
+	if err == nil {
+		t.Fatal("Expected certificate error for self-signed server")
+	}
+	if got := describeFetchError(err); !strings.HasPrefix(got, "TLS certificate error") {
+		t.Errorf("Expected TLS certificate error, got %q", got)
+	}
+}
+
+func TestCleanURL(t *testing.T) {
+	tests := []struct {
+		input, clean string
+		safe         bool
+	}{
+		{"HTTPS://Example.com/docs?utm_source=x&id=3", "https://example.com/docs?id=3", true},
+		{"https://example.com/page", "https://example.com/page", true},
+		{"https://Example.com/?fbclid=1", "https://example.com/", true},
+		// Dropping a trailing slash or a port can reach a different page.
+		{"https://example.com/docs/", "https://example.com/docs", false},
+		{"HTTPS://Example.com/docs/?utm_source=x", "https://example.com/docs", false},
+		{"https://example.com:443/page", "https://example.com/page", false},
+		// Escapes and fragments change the destination, so they are kept.
+		{"https://example.com/a%2Fb", "https://example.com/a%2Fb", true},
+		{"https://example.com/a%2Fb?utm_medium=x", "https://example.com/a%2Fb", true},
+		{"https://example.com/page#anchor", "https://example.com/page#anchor", true},
+		{"https://app.example.com/#/inbox", "https://app.example.com/#/inbox", true},
+		{"https://example.com/#!/item/4?fbclid=1", "https://example.com/#!/item/4?fbclid=1", true},
+	}
+	for _, test := range tests {
+		if clean, safe := cleanURL(test.input, defaultTrackingParams); clean != test.clean || safe != test.safe {
+			t.Errorf("cleanURL(%q) = %q, %v, expected %q, %v", test.input, clean, safe, test.clean, test.safe)
+		}
+	}
+}
+
+func TestNormalizeURLsSkipsTrailingSlash(t *testing.T) {
+	var updated []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/raindrops/0":
+			w.Write([]byte(`{"items": [
+				{"_id": 1, "link": "https://example.com/docs/"},
+				{"_id": 2, "link": "https://Example.com/page?utm_source=x"}
+			]}`))
+		case r.Method == "PUT":
+			updated = append(updated, r.URL.Path)
+			w.Write([]byte(`{"item": {}}`))
+		default:
+			t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := &RaindropClient{Token: "test-token", BaseURL: server.URL, TrackingParams: defaultTrackingParams}
+	resp, err := normalizeURLsHandler(client)(context.Background(), NormalizeURLsArgs{Apply: true, BulkOptions: BulkOptions{StopOnError: true}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(updated, []string{"/raindrop/2"}) {
+		t.Errorf("Expected only bookmark 2 to be updated, got %v", updated)
+	}
+	text := resp.Content[0].TextContent.Text
+	if !strings.Contains(text, "Normalized 1 of 2 URLs") || !strings.Contains(text, "Skipped 1 that could change the page the link opens:\nBookmark 1: https://example.com/docs/ -> https://example.com/docs") {
+		t.Errorf("Expected the trailing-slash form to be skipped, got %q", text)
+	}
+}
@@ -1,86 +1,52 @@
 package main
 
 import (
-	"encoding/json"
-	"errors"
+	"context"
 	"fmt"
 	"log"
-	"net/http"
-	"net/url"
 	"os"
-	"strings"
 
 	"github.com/joho/godotenv"
 	mcp "github.com/metoro-io/mcp-golang"
 	"github.com/metoro-io/mcp-golang/transport/stdio"
 )
 
-const RaindropAPIBase = "https://api.raindrop.io/rest/v1"
-
 // Raindrop Types
 type CreateBookmarkArgs struct {
-	URL        string   `json:"url" jsonschema:"required,description=URL to bookmark"`
-	Title      string   `json:"title,omitempty" jsonschema:"description=Title for the bookmark"`
-	Tags       []string `json:"tags,omitempty" jsonschema:"description=Array of tags"`
-	Collection int      `json:"collection,omitempty" jsonschema:"description=Collection ID"`
-}
-
-type SearchBookmarksArgs struct {
-	Query string   `json:"query" jsonschema:"required,description=Search query"`
-	Tags  []string `json:"tags,omitempty" jsonschema:"description=Array of tags to filter by"`
-}
-
-// RaindropAPI client
-type RaindropClient struct {
-	Token string
+	URL              string   `json:"url" jsonschema:"required,description=URL to bookmark"`
+	Title            string   `json:"title,omitempty" jsonschema:"description=Title for the bookmark"`
+	Tags             []string `json:"tags,omitempty" jsonschema:"description=Array of tags"`
+	Collection       int      `json:"collection,omitempty" jsonschema:"description=Collection ID"`
+	ResolveRedirects bool     `json:"resolveRedirects,omitempty" jsonschema:"description=Follow redirects and save the final URL"`
+	StripTracking    *bool    `json:"stripTracking,omitempty" jsonschema:"description=Remove tracking query parameters such as utm_* and fbclid (defaults to RAINDROP_STRIP_TRACKING)"`
+	Type             string   `json:"type,omitempty" jsonschema:"enum=link,enum=article,enum=image,enum=video,enum=document,enum=audio,description=Content type to save the bookmark as instead of letting Raindrop detect it"`
+	IdempotencyKey   string   `json:"idempotencyKey,omitempty" jsonschema:"description=Unique key for this save; retrying with the same key returns the first result instead of creating a duplicate"`
+	Parse            *bool    `json:"parse,omitempty" jsonschema:"description=Ask Raindrop to fetch the page and fill in title, excerpt, cover and type after saving (defaults to true when no title is given)"`
 }
 
-func NewRaindropClient() (*RaindropClient, error) {
-	token := os.Getenv("RAINDROP_TOKEN")
-	if token == "" {
-		return nil, errors.New("RAINDROP_TOKEN is not set")
-	}
-	return &RaindropClient{Token: token}, nil
+// SearchFilters are the search criteria shared by search-bookmarks and other
+// search-driven tools. They are documented for the model by searchHelpText;
+// update both together.
+type SearchFilters struct {
+	Query         string   `json:"query,omitempty" jsonschema:"description=Search text; may also contain raw Raindrop search operators"`
+	Tags          []string `json:"tags,omitempty" jsonschema:"description=Array of tags to filter by"`
+	TagsMatch     string   `json:"tagsMatch,omitempty" jsonschema:"enum=all,enum=any,description=Whether bookmarks need all tags or any of them (default all); any switches the whole search to match:OR"`
+	Type          string   `json:"type,omitempty" jsonschema:"enum=link,enum=article,enum=image,enum=video,enum=document,enum=audio,description=Only bookmarks of this content type"`
+	Domain        string   `json:"domain,omitempty" jsonschema:"description=Only bookmarks saved from this domain"`
+	CreatedAfter  string   `json:"createdAfter,omitempty" jsonschema:"description=Only bookmarks saved after this day (YYYY-MM-DD)"`
+	CreatedBefore string   `json:"createdBefore,omitempty" jsonschema:"description=Only bookmarks saved before this day (YYYY-MM-DD)"`
+	Important     bool     `json:"important,omitempty" jsonschema:"description=Only favorites"`
+	NoTag         bool     `json:"noTag,omitempty" jsonschema:"description=Only bookmarks without tags"`
+	Phrase        string   `json:"phrase,omitempty" jsonschema:"description=Exact phrase to match"`
+	Exclude       []string `json:"exclude,omitempty" jsonschema:"description=Words that must not appear"`
 }
 
-func (r *RaindropClient) MakeRequest(endpoint string, method string, body interface{}) (map[string]interface{}, error) {
-	url := fmt.Sprintf("%s%s", RaindropAPIBase, endpoint)
-
-	var reqBody []byte
-	var err error
-	if body != nil {
-		reqBody, err = json.Marshal(body)
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	req, err := http.NewRequest(method, url, strings.NewReader(string(reqBody)))
-	if err != nil {
-		return nil, err
-	}
-
-	req.Header.Set("Authorization", "Bearer "+r.Token)
-	req.Header.Set("Content-Type", "application/json")
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, fmt.Errorf("Raindrop API error: %s", resp.Status)
-	}
-
-	var result map[string]interface{}
-	err = json.NewDecoder(resp.Body).Decode(&result)
-	if err != nil {
-		return nil, err
-	}
-
-	return result, nil
+type SearchBookmarksArgs struct {
+	SearchFilters
+	Collection      int    `json:"collection,omitempty" jsonschema:"description=Collection ID to search (default 0: all bookmarks)"`
+	IncludeChildren bool   `json:"includeChildren,omitempty" jsonschema:"description=Also search the collection's nested sub-collections"`
+	ShowCollection  bool   `json:"showCollection,omitempty" jsonschema:"description=Include the name of each bookmark's collection"`
+	ClientSort      string `json:"clientSort,omitempty" jsonschema:"enum=title-length,enum=tag-count,enum=domain,description=Reorder the returned page of results: shortest title first, most tags first, or by domain. Only sorts within the fetched page"`
 }
 
 func main() {
@@ -104,93 +70,50 @@ func main() {
 	server := mcp.NewServer(stdio.NewStdioServerTransport(), mcp.WithName("Raindrop.io MCP Server"))
 
 	// Register tools
-	err = server.RegisterTool("create-bookmark", "Create a new bookmark in Raindrop.io",
-		func(args CreateBookmarkArgs) (*mcp.ToolResponse, error) {
-			if args.URL == "" {
-				return nil, fmt.Errorf("URL is required")
-			}
-
-			// Prepare the request body
-			body := map[string]interface{}{
-				"link":  args.URL,
-				"title": args.Title,
-				"tags":  args.Tags,
-			}
-
-			if args.Collection != 0 {
-				body["collection"] = map[string]interface{}{"$id": args.Collection}
-			} else {
-				body["collection"] = map[string]interface{}{"$id": 0}
-			}
-
-			bookmark, err := raindropClient.MakeRequest("/raindrop", "POST", body)
-			if err != nil {
-				return nil, fmt.Errorf("internal error: %v", err)
-			}
-
-			return mcp.NewToolResponse(
-				mcp.NewTextContent(fmt.Sprintf("Bookmark created successfully: %s", bookmark["link"])),
-			), nil
-		})
+	tools := &toolRegistry{server: server}
+	err = tools.register("create-bookmark", "Create a new bookmark in Raindrop.io", createBookmarkHandler(raindropClient))
 	if err != nil {
 		log.Fatalf("Failed to register create-bookmark tool: %v", err)
 	}
 
-	err = server.RegisterTool("search-bookmarks", "Search through your Raindrop.io bookmarks",
-		func(args SearchBookmarksArgs) (*mcp.ToolResponse, error) {
-			if args.Query == "" {
-				return nil, fmt.Errorf("query is required")
+	err = tools.register("search-bookmarks", "Search through your Raindrop.io bookmarks",
+		func(ctx context.Context, args SearchBookmarksArgs) (*mcp.ToolResponse, error) {
+			if err := validateSearchFilters(args.SearchFilters); err != nil {
+				return nil, err
+			}
+			if args.ClientSort != "" {
+				if err := validateClientSort(args.ClientSort); err != nil {
+					return nil, err
+				}
+			}
+			if args.IncludeChildren && args.Collection <= 0 {
+				return nil, fmt.Errorf("includeChildren needs a collection ID; collection %d has no sub-collections", args.Collection)
 			}
 
 			// Build query parameters
-			params := url.Values{}
-			params.Add("search", args.Query)
-			if len(args.Tags) > 0 {
-				params.Add("tags", strings.Join(args.Tags, ","))
+			query, _ := BuildSearchQuery(args)
+			cursor := searchCursor{
+				Collection:     args.Collection,
+				Page:           0,
+				PerPage:        searchPageSize,
+				Search:         query,
+				Nested:         args.IncludeChildren,
+				ClientSort:     args.ClientSort,
+				ShowCollection: args.ShowCollection,
 			}
 
-			endpoint := fmt.Sprintf("/raindrops/0?%s", params.Encode())
-			results, err := raindropClient.MakeRequest(endpoint, "GET", nil)
+			items, returned, err := raindropClient.searchPage(ctx, cursor)
 			if err != nil {
-				return nil, fmt.Errorf("internal error: %v", err)
+				return nil, err
 			}
-
-			items, ok := results["items"].([]interface{})
-			if !ok {
-				return nil, fmt.Errorf("unable to parse results")
-			}
-
-			var formattedResults strings.Builder
-			for _, item := range items {
-				bookmark, ok := item.(map[string]interface{})
-				if !ok {
-					continue
-				}
-
-				title, _ := bookmark["title"].(string)
-				link, _ := bookmark["link"].(string)
-
-				// Extract tags
-				tagList := []string{}
-				if tags, ok := bookmark["tags"].([]interface{}); ok {
-					for _, t := range tags {
-						if tag, ok := t.(string); ok {
-							tagList = append(tagList, tag)
-						}
-					}
-				}
-
-				tagsStr := "No tags"
-				if len(tagList) > 0 {
-					tagsStr = strings.Join(tagList, ", ")
-				}
-
-				formattedResults.WriteString(fmt.Sprintf("\nTitle: %s\nURL: %s\nTags: %s\n---", title, link, tagsStr))
+			formattedResults, err := raindropClient.formatSearchPage(ctx, cursor, items)
+			if err != nil {
+				return nil, err
 			}
 
 			var responseText string
 			if len(items) > 0 {
-				responseText = fmt.Sprintf("Found %d bookmarks:%s", len(items), formattedResults.String())
+				responseText = fmt.Sprintf("Found %d bookmarks:%s%s", len(items), formattedResults, nextCursorNote(cursor, returned))
 			} else {
 				responseText = "No bookmarks found matching your search."
 			}
@@ -203,6 +126,406 @@ func main() {
 		log.Fatalf("Failed to register search-bookmarks tool: %v", err)
 	}
 
+	err = tools.register("export-markdown", "Export a Raindrop.io collection as a Markdown reading list", exportMarkdownHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register export-markdown tool: %v", err)
+	}
+
+	err = tools.register("import-urls", "Save a list of URLs as Raindrop.io bookmarks in one batch", importURLsHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register import-urls tool: %v", err)
+	}
+
+	err = tools.register("set-created-date", "Set a bookmark's created date, e.g. to preserve original save dates when migrating", setCreatedDateHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register set-created-date tool: %v", err)
+	}
+
+	err = tools.register("list-trash", "List bookmarks in the Raindrop.io trash with when they were last updated (Raindrop records no deletion time)", listTrashHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register list-trash tool: %v", err)
+	}
+
+	err = tools.register("refresh-metadata", "Re-parse a bookmark's page and fill in missing title, excerpt or cover", refreshMetadataHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register refresh-metadata tool: %v", err)
+	}
+
+	err = tools.register("search-help", "Describe the search operators supported by search-bookmarks", searchHelpHandler())
+	if err != nil {
+		log.Fatalf("Failed to register search-help tool: %v", err)
+	}
+
+	err = tools.register("get-bookmark-location", "Show where a bookmark lives as a collection breadcrumb, e.g. Programming > Go", getBookmarkLocationHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register get-bookmark-location tool: %v", err)
+	}
+
+	err = tools.register("rename-bookmark", "Change only the title of a bookmark", renameBookmarkHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register rename-bookmark tool: %v", err)
+	}
+
+	err = tools.register("find-incomplete", "Find bookmarks with an empty title or excerpt", findIncompleteHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register find-incomplete tool: %v", err)
+	}
+
+	err = tools.register("subtree-tags", "List tag counts across a collection and all of its nested collections", subtreeTagsHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register subtree-tags tool: %v", err)
+	}
+
+	err = tools.register("find-stale-collections", "Find collections with no new or updated bookmarks in a number of days", findStaleCollectionsHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register find-stale-collections tool: %v", err)
+	}
+
+	err = tools.register("swap-collection", "Move a bookmark to whichever of two collections it is not currently in", swapCollectionHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register swap-collection tool: %v", err)
+	}
+
+	err = tools.register("export-feed", "Export a Raindrop.io collection as an RSS or Atom feed", exportFeedHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register export-feed tool: %v", err)
+	}
+
+	err = tools.register("list-filters", "Show Raindrop.io's built-in filters (favorites, untagged, broken, duplicates) with counts for a collection", listFiltersHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register list-filters tool: %v", err)
+	}
+
+	err = tools.register("set-collection-cover-from-item", "Use a bookmark's cover image as its collection's cover", setCollectionCoverFromItemHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register set-collection-cover-from-item tool: %v", err)
+	}
+
+	err = tools.register("create-collection-path", "Create nested collections from a path like Work/Projects/Go, reusing ones that exist", createCollectionPathHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register create-collection-path tool: %v", err)
+	}
+
+	err = tools.register("library-health", "Summarize how clean the library is: untagged, broken, duplicate, unsorted and trashed bookmarks", libraryHealthHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register library-health tool: %v", err)
+	}
+
+	err = tools.register("merge-bookmarks", "Merge one bookmark's tags, excerpt and note into another and delete it", mergeBookmarksHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register merge-bookmarks tool: %v", err)
+	}
+
+	err = tools.register("list-urls", "Search bookmarks and return only the matching URLs, one per line", listURLsHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register list-urls tool: %v", err)
+	}
+
+	err = tools.register("set-collections-public", "Make several collections public or private at once", setCollectionsPublicHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register set-collections-public tool: %v", err)
+	}
+
+	err = tools.register("get-note", "Get the private note of a bookmark (not its excerpt)", getNoteHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register get-note tool: %v", err)
+	}
+
+	err = tools.register("set-note", "Set or clear the private note of a bookmark (not its excerpt)", setNoteHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register set-note tool: %v", err)
+	}
+
+	err = tools.register("bookmarks-on-date", "List bookmarks saved on a specific day", bookmarksOnDateHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register bookmarks-on-date tool: %v", err)
+	}
+
+	err = tools.register("ping", "Check that the Raindrop.io API is reachable and report latency and remaining rate limit", pingHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register ping tool: %v", err)
+	}
+
+	err = tools.register("type-breakdown", "Show how many bookmarks of each content type a collection holds", typeBreakdownHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register type-breakdown tool: %v", err)
+	}
+
+	err = tools.register("save-session", "Save a set of browser tabs as a named session of bookmarks", saveSessionHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register save-session tool: %v", err)
+	}
+
+	err = tools.register("list-session", "List the URLs of a saved session so they can be reopened", listSessionHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register list-session tool: %v", err)
+	}
+
+	err = tools.register("auto-tag", "Add tags to bookmarks whose title, excerpt or domain contains a keyword", autoTagHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register auto-tag tool: %v", err)
+	}
+
+	err = tools.register("diff-collections", "Compare the URLs of two collections", diffCollectionsHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register diff-collections tool: %v", err)
+	}
+
+	err = tools.register("export-json", "Export a collection as JSON for backup or migration", exportJSONHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register export-json tool: %v", err)
+	}
+
+	err = tools.register("import-json", "Restore bookmarks from an export-json backup", importJSONHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register import-json tool: %v", err)
+	}
+
+	err = tools.register("check-url", "Check whether a URL is reachable before bookmarking it", checkURLHandler())
+	if err != nil {
+		log.Fatalf("Failed to register check-url tool: %v", err)
+	}
+
+	err = tools.register("regenerate-cover", "Regenerate or clear a bookmark's cover image", regenerateCoverHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register regenerate-cover tool: %v", err)
+	}
+
+	err = tools.register("find-highlighted", "List bookmarks that have highlights", findHighlightedHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register find-highlighted tool: %v", err)
+	}
+
+	err = tools.register("collection-gallery", "List the cover images of a collection's bookmarks for a thumbnail grid", collectionGalleryHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register collection-gallery tool: %v", err)
+	}
+
+	err = tools.register("list-shared", "List collections shared with collaborators and your role in each", listSharedHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register list-shared tool: %v", err)
+	}
+
+	err = tools.register("tag-by-domain", "Tag bookmarks with the name of the site they were saved from", tagByDomainHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register tag-by-domain tool: %v", err)
+	}
+
+	err = tools.register("export-html", "Export a collection as a browser-importable bookmarks HTML file", exportHTMLHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register export-html tool: %v", err)
+	}
+
+	err = tools.register("get-bookmark-tags", "Get the tags of a bookmark as a JSON array", getBookmarkTagsHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register get-bookmark-tags tool: %v", err)
+	}
+
+	err = tools.register("bulk-remove-tag", "Remove a tag from specific bookmarks", bulkRemoveTagHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register bulk-remove-tag tool: %v", err)
+	}
+
+	err = tools.register("preview-url", "Show what Raindrop would save for a URL without saving it", previewURLHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register preview-url tool: %v", err)
+	}
+
+	err = tools.register("consolidate-collection", "Move every bookmark of a collection into another and delete the emptied collection", consolidateCollectionHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register consolidate-collection tool: %v", err)
+	}
+
+	err = tools.register("suggest-fix-broken", "Suggest an archived replacement for a broken bookmark", suggestFixBrokenHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register suggest-fix-broken tool: %v", err)
+	}
+
+	err = tools.register("rescue-broken-links", "Replace broken links with their Wayback Machine copies", rescueBrokenLinksHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register rescue-broken-links tool: %v", err)
+	}
+
+	err = tools.register("set-collection-item-sort", "Set the default order of a collection's bookmarks", setCollectionItemSortHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register set-collection-item-sort tool: %v", err)
+	}
+
+	err = tools.register("find-forgotten", "List Unsorted bookmarks saved more than N days ago", findForgottenHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register find-forgotten tool: %v", err)
+	}
+
+	err = tools.register("find-similar-titles", "Group bookmarks with near-identical titles", findSimilarTitlesHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register find-similar-titles tool: %v", err)
+	}
+
+	err = tools.register("file-in-multiple", "File a bookmark in several collections by creating linked copies", fileInMultipleHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register file-in-multiple tool: %v", err)
+	}
+
+	err = tools.register("top-domains", "List the domains most bookmarks were saved from", topDomainsHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register top-domains tool: %v", err)
+	}
+
+	err = tools.register("backfill-excerpts", "Fill in missing excerpts from page descriptions", backfillExcerptsHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register backfill-excerpts tool: %v", err)
+	}
+
+	err = tools.register("search-continue", "Fetch the next page of a search using its nextCursor token", searchContinueHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register search-continue tool: %v", err)
+	}
+
+	err = tools.register("bulk-rename-titles", "Find and replace text in the titles of a collection's bookmarks", bulkRenameTitlesHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register bulk-rename-titles tool: %v", err)
+	}
+
+	err = tools.register("get-bookmark-full", "Get every stored field of a bookmark as JSON", getBookmarkFullHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register get-bookmark-full tool: %v", err)
+	}
+
+	err = tools.register("quick-save", "Save the first link found in pasted text, using the rest as title and excerpt", quickSaveHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register quick-save tool: %v", err)
+	}
+
+	err = tools.register("server-info", "Describe the server's version, configuration and registered tools", serverInfoHandler(raindropClient, tools))
+	if err != nil {
+		log.Fatalf("Failed to register server-info tool: %v", err)
+	}
+
+	err = tools.register("poll-collection", "Return bookmarks added to a collection since the last poll", pollCollectionHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register poll-collection tool: %v", err)
+	}
+
+	err = tools.register("preview-tags", "Show the tags a URL would be saved with under the default tags, auto-tag rules and domain rule, without saving", previewTagsHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register preview-tags tool: %v", err)
+	}
+
+	err = tools.register("normalize-urls", "Normalize bookmark URLs in a collection (tracking parameters, host case), skipping changes that could open a different page; dry run unless apply is set", normalizeURLsHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register normalize-urls tool: %v", err)
+	}
+
+	err = tools.register("save-feed", "Save the latest entries of an RSS or Atom feed as Raindrop.io bookmarks", saveFeedHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register save-feed tool: %v", err)
+	}
+
+	err = tools.register("weekly-digest", "Summarize the bookmarks saved in the last N days as a Markdown digest grouped by day", weeklyDigestHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register weekly-digest tool: %v", err)
+	}
+
+	err = tools.register("find-tag-typos", "Find groups of tags that are likely misspellings of each other, ranked by combined usage", findTagTyposHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register find-tag-typos tool: %v", err)
+	}
+
+	err = tools.register("get-collection-access", "Show your access level on a collection, whether you can edit it, and who owns it", getCollectionAccessHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register get-collection-access tool: %v", err)
+	}
+
+	err = tools.register("star-search-results", "Mark every bookmark matching a search as a favorite", starSearchResultsHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register star-search-results tool: %v", err)
+	}
+
+	err = tools.register("clear-favorites", "Unstar every favorite in a collection", clearFavoritesHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register clear-favorites tool: %v", err)
+	}
+
+	err = tools.register("split-by-tag", "Split a collection into sub-collections named after tags, moving each tagged bookmark into its tag's sub-collection", splitByTagHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register split-by-tag tool: %v", err)
+	}
+
+	err = tools.register("get-reminder", "Get the reminder set on a bookmark", getReminderHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register get-reminder tool: %v", err)
+	}
+
+	err = tools.register("clear-reminder", "Remove the reminder from a bookmark", clearReminderHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register clear-reminder tool: %v", err)
+	}
+
+	err = tools.register("list-reminders", "List bookmarks whose reminders are due soon, as an agenda ordered by reminder date", listRemindersHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register list-reminders tool: %v", err)
+	}
+
+	err = tools.register("reading-time", "Estimate how long it would take to read the bookmarks in a collection", readingTimeHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register reading-time tool: %v", err)
+	}
+
+	err = tools.register("tag-cooccurrence", "List the tags most often used together with a given tag", tagCooccurrenceHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register tag-cooccurrence tool: %v", err)
+	}
+
+	err = tools.register("auto-file-inbox", "Move Unsorted bookmarks into the collections Raindrop suggests for them; dry run unless apply is set", autoFileInboxHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register auto-file-inbox tool: %v", err)
+	}
+
+	err = tools.register("tag-by-language", "Tag bookmarks with lang:xx based on the language of their title and excerpt", tagByLanguageHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register tag-by-language tool: %v", err)
+	}
+
+	err = tools.register("export-highlights-markdown", "Export the highlights and notes of a collection as a Markdown document grouped by bookmark", exportHighlightsMarkdownHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register export-highlights-markdown tool: %v", err)
+	}
+
+	err = tools.register("use-account", "Switch the Raindrop account that subsequent tool calls use", useAccountHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register use-account tool: %v", err)
+	}
+
+	err = tools.register("list-accounts", "List the configured Raindrop accounts, their emails and which one is active", listAccountsHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register list-accounts tool: %v", err)
+	}
+
+	err = tools.register("backup-to-file", "Write a JSON backup of a collection to a file inside RAINDROP_BACKUP_DIR", backupToFileHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register backup-to-file tool: %v", err)
+	}
+
+	err = tools.register("run-saved-filter", "Run one of Raindrop.io's built-in filters (favorites, untagged, broken, duplicates, ...) by name", runSavedFilterHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register run-saved-filter tool: %v", err)
+	}
+
+	err = tools.register("storage-usage", "Show how much of the account's file upload quota is used", storageUsageHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register storage-usage tool: %v", err)
+	}
+
+	err = tools.register("save-to-named-collection", "Save a bookmark into a collection given by name, creating the collection if it does not exist", saveToNamedCollectionHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register save-to-named-collection tool: %v", err)
+	}
+
+	err = tools.register("tag-cloud", "List tags with a 1-5 weight by how often they are used, for rendering a tag cloud", tagCloudHandler(raindropClient))
+	if err != nil {
+		log.Fatalf("Failed to register tag-cloud tool: %v", err)
+	}
+
 	// Start the server
 	if err := server.Serve(); err != nil {
 		log.Fatalf("Server error: %v", err)
@@ -10,6 +10,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	mcp "github.com/metoro-io/mcp-golang"
 )
@@ -176,4 +177,43 @@ func TestCreateToolHandler(t *testing.T) {
 	
 	// This is just a compile-time check that our handler function signature is correct
 	_ = handler
-}
\ No newline at end of file
+}
+
+func TestDurationFromEnv(t *testing.T) {
+	originalTimeout := os.Getenv("RAINDROP_BULK_TIMEOUT")
+	defer os.Setenv("RAINDROP_BULK_TIMEOUT", originalTimeout)
+
+	os.Setenv("RAINDROP_BULK_TIMEOUT", "")
+	d, err := durationFromEnv("RAINDROP_BULK_TIMEOUT", DefaultBulkTimeout)
+	if err != nil || d != DefaultBulkTimeout {
+		t.Errorf("Expected default %v, got %v (err: %v)", DefaultBulkTimeout, d, err)
+	}
+
+	os.Setenv("RAINDROP_BULK_TIMEOUT", "10m")
+	d, err = durationFromEnv("RAINDROP_BULK_TIMEOUT", DefaultBulkTimeout)
+	if err != nil || d != 10*time.Minute {
+		t.Errorf("Expected 10m, got %v (err: %v)", d, err)
+	}
+
+	for _, invalid := range []string{"ten minutes", "-1s", "0"} {
+		os.Setenv("RAINDROP_BULK_TIMEOUT", invalid)
+		if _, err := durationFromEnv("RAINDROP_BULK_TIMEOUT", DefaultBulkTimeout); err == nil {
+			t.Errorf("Expected error for %q", invalid)
+		}
+	}
+}
+
+func TestBulkContext(t *testing.T) {
+	client := &RaindropClient{Token: "test-token", Timeout: time.Second, BulkTimeout: time.Hour}
+
+	ctx, cancel := client.bulkContext(context.Background())
+	defer cancel()
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		t.Fatal("Expected bulk context to have a deadline")
+	}
+	if remaining := time.Until(deadline); remaining < 59*time.Minute {
+		t.Errorf("Expected bulk deadline about an hour away, got %v", remaining)
+	}
+}
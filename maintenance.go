@@ -0,0 +1,349 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	mcp "github.com/metoro-io/mcp-golang"
+)
+
+type FindIncompleteArgs struct {
+	Collection int    `json:"collection,omitempty" jsonschema:"description=Collection ID to scan (0 for all bookmarks)"`
+	Missing    string `json:"missing,omitempty" jsonschema:"enum=title,enum=excerpt,enum=both,description=Which empty field to look for; both matches either (default both)"`
+	MaxItems   int    `json:"maxItems,omitempty" jsonschema:"description=Maximum number of bookmarks to scan (default and cap 1000)"`
+}
+
+// missingFields returns the names of the checked fields that are empty on bookmark.
+func missingFields(bookmark map[string]interface{}, missing string) []string {
+	var fields []string
+	if missing != "excerpt" && strings.TrimSpace(stringField(bookmark, "title")) == "" {
+		fields = append(fields, "title")
+	}
+	if missing != "title" && strings.TrimSpace(stringField(bookmark, "excerpt")) == "" {
+		fields = append(fields, "excerpt")
+	}
+	return fields
+}
+
+func findIncompleteHandler(client *RaindropClient) func(ctx context.Context, args FindIncompleteArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args FindIncompleteArgs) (*mcp.ToolResponse, error) {
+		missing := args.Missing
+		if missing == "" {
+			missing = "both"
+		}
+		if missing != "title" && missing != "excerpt" && missing != "both" {
+			return nil, fmt.Errorf("missing must be one of title, excerpt or both")
+		}
+
+		ctx, cancel := client.bulkContext(ctx)
+		defer cancel()
+
+		items, err := client.listRaindrops(ctx, args.Collection, url.Values{}, exportLimit(args.MaxItems))
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+
+		var formattedResults strings.Builder
+		found := 0
+		for _, bookmark := range items {
+			fields := missingFields(bookmark, missing)
+			if len(fields) == 0 {
+				continue
+			}
+			found++
+			formattedResults.WriteString(fmt.Sprintf("\nID: %d\nURL: %s\nMissing: %s\n---",
+				intField(bookmark, "_id"), stringField(bookmark, "link"), strings.Join(fields, ", ")))
+		}
+
+		if found == 0 {
+			return mcp.NewToolResponse(
+				mcp.NewTextContent(fmt.Sprintf("All %d scanned bookmarks have the checked fields.", len(items))),
+			), nil
+		}
+
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(fmt.Sprintf("Found %d of %d bookmarks with missing fields:%s", found, len(items), formattedResults.String())),
+		), nil
+	}
+}
+
+type FindStaleCollectionsArgs struct {
+	OlderThanDays int `json:"olderThanDays" jsonschema:"required,description=Report collections with no activity in this many days"`
+}
+
+// staleCollection is a collection together with its most recent activity.
+type staleCollection struct {
+	ID           int
+	Title        string
+	LastActivity time.Time
+}
+
+// activityTime returns the newest of an item's lastUpdate and created timestamps.
+func activityTime(item map[string]interface{}) time.Time {
+	var latest time.Time
+	for _, field := range []string{"lastUpdate", "created"} {
+		if t, err := time.Parse(time.RFC3339, stringField(item, field)); err == nil && t.After(latest) {
+			latest = t
+		}
+	}
+	return latest
+}
+
+// latestActivity returns when anything in a collection was last updated, using a
+// single one-item query sorted by lastUpdate.
+func (r *RaindropClient) latestActivity(ctx context.Context, collection map[string]interface{}) (time.Time, error) {
+	params := url.Values{}
+	params.Set("sort", "-lastUpdate")
+	params.Set("perpage", "1")
+
+	result, err := r.MakeRequest(ctx, fmt.Sprintf("/raindrops/%d?%s", intField(collection, "_id"), params.Encode()), "GET", nil)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	latest := activityTime(collection)
+	if items, ok := result["items"].([]interface{}); ok && len(items) > 0 {
+		if item, ok := items[0].(map[string]interface{}); ok {
+			if t := activityTime(item); t.After(latest) {
+				latest = t
+			}
+		}
+	}
+	return latest, nil
+}
+
+func findStaleCollectionsHandler(client *RaindropClient) func(ctx context.Context, args FindStaleCollectionsArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args FindStaleCollectionsArgs) (*mcp.ToolResponse, error) {
+		if args.OlderThanDays <= 0 {
+			return nil, fmt.Errorf("olderThanDays must be positive")
+		}
+		cutoff := time.Now().AddDate(0, 0, -args.OlderThanDays)
+
+		ctx, cancel := client.bulkContext(ctx)
+		defer cancel()
+
+		collections, err := client.fetchCollections(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+
+		var stale []staleCollection
+		for id, collection := range collections {
+			latest, err := client.latestActivity(ctx, collection)
+			if err != nil {
+				return nil, fmt.Errorf("internal error: %v", err)
+			}
+			if latest.Before(cutoff) {
+				stale = append(stale, staleCollection{ID: id, Title: stringField(collection, "title"), LastActivity: latest})
+			}
+		}
+
+		if len(stale) == 0 {
+			return mcp.NewToolResponse(
+				mcp.NewTextContent(fmt.Sprintf("All %d collections had activity in the last %d days.", len(collections), args.OlderThanDays)),
+			), nil
+		}
+
+		sort.Slice(stale, func(i, j int) bool {
+			return stale[i].LastActivity.Before(stale[j].LastActivity)
+		})
+
+		var formattedResults strings.Builder
+		for _, collection := range stale {
+			lastActivity := "never"
+			if !collection.LastActivity.IsZero() {
+				lastActivity = collection.LastActivity.Format("2006-01-02")
+			}
+			formattedResults.WriteString(fmt.Sprintf("\nID: %d\nTitle: %s\nLast activity: %s\n---", collection.ID, collection.Title, lastActivity))
+		}
+
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(fmt.Sprintf("Found %d collections untouched for %d days:%s", len(stale), args.OlderThanDays, formattedResults.String())),
+		), nil
+	}
+}
+
+type LibraryHealthArgs struct{}
+
+func libraryHealthHandler(client *RaindropClient) func(ctx context.Context, args LibraryHealthArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args LibraryHealthArgs) (*mcp.ToolResponse, error) {
+		filters, err := client.fetchFilters(ctx, 0)
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+
+		counts := map[int]int{}
+		for _, collection := range []int{0, unsortedCollectionID, trashCollectionID} {
+			count, err := client.CountMatches(ctx, collection, nil)
+			if err != nil {
+				return nil, fmt.Errorf("internal error: %v", err)
+			}
+			counts[collection] = count
+		}
+
+		var report strings.Builder
+		report.WriteString("Library health:\n")
+		report.WriteString(fmt.Sprintf("Bookmarks: %d\n", counts[0]))
+		report.WriteString(fmt.Sprintf("Untagged: %d", filters.Untagged))
+		if filters.Untagged > 0 {
+			report.WriteString(" (search with notag:true to tag them)")
+		}
+		report.WriteString(fmt.Sprintf("\nBroken links: %d", filters.Broken))
+		if filters.Broken > 0 {
+			report.WriteString(" (search with broken:true to review them)")
+		}
+		report.WriteString(fmt.Sprintf("\nDuplicates: %d", filters.Duplicates))
+		report.WriteString(fmt.Sprintf("\nUnsorted: %d", counts[unsortedCollectionID]))
+		if counts[unsortedCollectionID] > 0 {
+			report.WriteString(" (file them into collections)")
+		}
+		report.WriteString(fmt.Sprintf("\nIn trash: %d", counts[trashCollectionID]))
+		if counts[trashCollectionID] > 0 {
+			report.WriteString(" (review with list-trash)")
+		}
+
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(report.String()),
+		), nil
+	}
+}
+
+type FindForgottenArgs struct {
+	OlderThanDays int `json:"olderThanDays" jsonschema:"required,description=Only list Unsorted bookmarks saved more than this many days ago"`
+}
+
+func findForgottenHandler(client *RaindropClient) func(ctx context.Context, args FindForgottenArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args FindForgottenArgs) (*mcp.ToolResponse, error) {
+		if args.OlderThanDays <= 0 {
+			return nil, fmt.Errorf("olderThanDays must be positive")
+		}
+		now := time.Now().UTC()
+		cutoff := now.AddDate(0, 0, -args.OlderThanDays)
+
+		params := url.Values{}
+		params.Set("search", "created:<"+cutoff.Format("2006-01-02"))
+		params.Set("sort", "created")
+
+		ctx, cancel := client.bulkContext(ctx)
+		defer cancel()
+
+		items, err := client.listRaindrops(ctx, unsortedCollectionID, params, maxExportItems)
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+
+		if len(items) == 0 {
+			return mcp.NewToolResponse(
+				mcp.NewTextContent(fmt.Sprintf("No Unsorted bookmarks older than %d days.", args.OlderThanDays)),
+			), nil
+		}
+
+		var formattedResults strings.Builder
+		for _, item := range items {
+			age := "unknown"
+			if created, err := parseDate(stringField(item, "created")); err == nil {
+				age = fmt.Sprintf("%d days", int(now.Sub(created).Hours()/24))
+			}
+			formattedResults.WriteString(fmt.Sprintf("\nID: %d\nTitle: %s\nURL: %s\nAge: %s\n---",
+				intField(item, "_id"), stringField(item, "title"), stringField(item, "link"), age))
+		}
+
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(fmt.Sprintf("Found %d Unsorted bookmarks older than %d days:%s", len(items), args.OlderThanDays, formattedResults.String())),
+		), nil
+	}
+}
+
+// suggestedDestination returns the first suggested collection the user has,
+// skipping system collections and collections that no longer exist.
+func suggestedDestination(suggestions []int, collections map[int]map[string]interface{}) (int, bool) {
+	for _, id := range suggestions {
+		if _, ok := collections[id]; ok && id > 0 {
+			return id, true
+		}
+	}
+	return 0, false
+}
+
+type AutoFileInboxArgs struct {
+	BulkOptions
+	Apply bool `json:"apply,omitempty" jsonschema:"description=Move the bookmarks; without it only the planned moves are shown"`
+}
+
+func autoFileInboxHandler(client *RaindropClient) func(ctx context.Context, args AutoFileInboxArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args AutoFileInboxArgs) (*mcp.ToolResponse, error) {
+		ctx, cancel := client.bulkContext(ctx)
+		defer cancel()
+
+		collections, err := client.fetchCollections(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+		items, err := client.listRaindrops(ctx, unsortedCollectionID, url.Values{}, maxExportItems)
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+		if len(items) == 0 {
+			return mcp.NewToolResponse(
+				mcp.NewTextContent("Unsorted is empty."),
+			), nil
+		}
+
+		destinations := make([]int, len(items))
+		errs := client.forEachConcurrent(ctx, len(items), func(ctx context.Context, i int) error {
+			suggestions, err := client.suggestCollections(ctx, intField(items[i], "_id"))
+			if err != nil {
+				return err
+			}
+			destinations[i], _ = suggestedDestination(suggestions, collections)
+			return nil
+		})
+
+		var ids []int
+		planned := map[int]int{}
+		titles := map[int]string{}
+		var unfiled strings.Builder
+		for i, item := range items {
+			id := intField(item, "_id")
+			switch {
+			case errs[i] != nil:
+				unfiled.WriteString(fmt.Sprintf("\nBookmark %d (%s): no suggestion (%v)", id, stringField(item, "title"), errs[i]))
+			case destinations[i] == 0:
+				unfiled.WriteString(fmt.Sprintf("\nBookmark %d (%s): no suggestion", id, stringField(item, "title")))
+			default:
+				ids = append(ids, id)
+				planned[id] = destinations[i]
+				titles[id] = stringField(item, "title")
+			}
+		}
+
+		result := newBulkResult(ids, make([]error, len(ids)))
+		if args.Apply {
+			result = client.bulkApply(ctx, ids, args.StopOnError, func(ctx context.Context, id int) error {
+				_, err := client.updateRaindrop(ctx, id, map[string]interface{}{"collection": map[string]interface{}{"$id": planned[id]}})
+				return err
+			})
+		}
+
+		verb := "Moved"
+		if !args.Apply {
+			verb = "Would move"
+		}
+
+		var report strings.Builder
+		report.WriteString(fmt.Sprintf("%s %d of %d Unsorted bookmarks:", verb, len(result.Succeeded), len(items)))
+		for _, id := range result.Succeeded {
+			report.WriteString(fmt.Sprintf("\nBookmark %d (%s) -> %s", id, titles[id], strings.Join(collectionPath(collections, planned[id]), " > ")))
+		}
+		report.WriteString(result.FailedText())
+		report.WriteString(unfiled.String())
+
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(report.String()),
+		), nil
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMissingFields(t *testing.T) {
+	bookmark := map[string]interface{}{"title": " ", "excerpt": "Has an excerpt"}
+
+	tests := map[string][]string{
+		"title":   {"title"},
+		"excerpt": nil,
+		"both":    {"title"},
+	}
+	for missing, expected := range tests {
+		if got := missingFields(bookmark, missing); !reflect.DeepEqual(got, expected) {
+			t.Errorf("missingFields(%q) = %v, expected %v", missing, got, expected)
+		}
+	}
+
+	empty := map[string]interface{}{}
+	if got := missingFields(empty, "both"); !reflect.DeepEqual(got, []string{"title", "excerpt"}) {
+		t.Errorf("Expected both fields missing, got %v", got)
+	}
+}
+
+func TestActivityTime(t *testing.T) {
+	item := map[string]interface{}{
+		"created":    "2023-01-01T00:00:00Z",
+		"lastUpdate": "2023-06-01T12:00:00Z",
+	}
+	if got := activityTime(item); !got.Equal(time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected lastUpdate to win, got %v", got)
+	}
+
+	item["lastUpdate"] = "not a date"
+	if got := activityTime(item); !got.Equal(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected created fallback, got %v", got)
+	}
+
+	if got := activityTime(map[string]interface{}{}); !got.IsZero() {
+		t.Errorf("Expected zero time, got %v", got)
+	}
+}
+
+func TestSuggestedDestination(t *testing.T) {
+	collections := map[int]map[string]interface{}{
+		10: {"_id": float64(10), "title": "Reading"},
+		20: {"_id": float64(20), "title": "Work"},
+	}
+
+	if id, ok := suggestedDestination([]int{unsortedCollectionID, 99, 20, 10}, collections); !ok || id != 20 {
+		t.Errorf("Expected the first existing collection 20, got %d, %v", id, ok)
+	}
+	if _, ok := suggestedDestination([]int{0, 99}, collections); ok {
+		t.Error("Expected no destination without an existing collection")
+	}
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	mcp "github.com/metoro-io/mcp-golang"
+)
+
+// pollFromStart is the sinceId the first poll returns for an empty collection.
+// Every bookmark is newer than it, so the next poll reports the first saves
+// instead of starting to watch again.
+const pollFromStart = -1
+
+// errStopPaging ends eachRaindropPage early once a caller has the items it needs.
+var errStopPaging = errors.New("stop paging")
+
+// newerThan returns the items whose ID is above sinceID, and whether the list
+// reached an item at or below it.
+func newerThan(items []map[string]interface{}, sinceID int) ([]map[string]interface{}, bool) {
+	var newer []map[string]interface{}
+	reached := false
+	for _, item := range items {
+		if intField(item, "_id") > sinceID {
+			newer = append(newer, item)
+		} else {
+			reached = true
+		}
+	}
+	return newer, reached
+}
+
+// latestID returns the highest ID among items, or fallback when there are none.
+func latestID(items []map[string]interface{}, fallback int) int {
+	latest := fallback
+	for _, item := range items {
+		if id := intField(item, "_id"); id > latest {
+			latest = id
+		}
+	}
+	return latest
+}
+
+type PollCollectionArgs struct {
+	CollectionID int `json:"collectionId" jsonschema:"description=Collection ID to watch (0 for all bookmarks)"`
+	SinceID      int `json:"sinceId,omitempty" jsonschema:"description=sinceId returned by the previous poll; omit on the first poll"`
+}
+
+func pollCollectionHandler(client *RaindropClient) func(ctx context.Context, args PollCollectionArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args PollCollectionArgs) (*mcp.ToolResponse, error) {
+		params := url.Values{}
+		params.Set("sort", "-created")
+
+		if args.SinceID == 0 {
+			items, err := client.listRaindrops(ctx, args.CollectionID, params, 1)
+			if err != nil {
+				return nil, fmt.Errorf("internal error: %v", err)
+			}
+			return mcp.NewToolResponse(
+				mcp.NewTextContent(fmt.Sprintf("Watching collection %d. Poll again with sinceId %d.", args.CollectionID, latestID(items, pollFromStart))),
+			), nil
+		}
+
+		ctx, cancel := client.bulkContext(ctx)
+		defer cancel()
+
+		// Raindrop IDs increase with every save, so newest-first pages can stop
+		// at the first item that is not newer than sinceId.
+		var newer []map[string]interface{}
+		err := client.eachRaindropPage(ctx, args.CollectionID, params, maxExportItems, func(page []map[string]interface{}) error {
+			items, reached := newerThan(page, args.SinceID)
+			newer = append(newer, items...)
+			if reached {
+				return errStopPaging
+			}
+			return nil
+		})
+		if err != nil && !errors.Is(err, errStopPaging) {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+
+		next := latestID(newer, args.SinceID)
+		if len(newer) == 0 {
+			return mcp.NewToolResponse(
+				mcp.NewTextContent(fmt.Sprintf("No new bookmarks. Poll again with sinceId %d.", next)),
+			), nil
+		}
+
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(fmt.Sprintf("Found %d new bookmarks:%s\n\nPoll again with sinceId %d.", len(newer), formatBookmarks(newer), next)),
+		), nil
+	}
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNewerThan(t *testing.T) {
+	items := []map[string]interface{}{
+		{"_id": float64(105)},
+		{"_id": float64(103)},
+		{"_id": float64(100)},
+		{"_id": float64(98)},
+	}
+
+	newer, reached := newerThan(items, 100)
+	if len(newer) != 2 || !reached {
+		t.Errorf("Expected two newer items and the cutoff reached, got %v, %v", newer, reached)
+	}
+	if got := latestID(newer, 100); got != 105 {
+		t.Errorf("Expected latest ID 105, got %d", got)
+	}
+
+	if _, reached := newerThan(items[:2], 100); reached {
+		t.Error("Expected cutoff not to be reached")
+	}
+	if got := latestID(nil, 100); got != 100 {
+		t.Errorf("Expected fallback ID, got %d", got)
+	}
+}
+
+func TestPollEmptyCollection(t *testing.T) {
+	items := `[]`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"items": ` + items + `}`))
+	}))
+	defer server.Close()
+
+	client := &RaindropClient{Token: "test-token", BaseURL: server.URL}
+	poll := pollCollectionHandler(client)
+
+	resp, err := poll(context.Background(), PollCollectionArgs{CollectionID: 1})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if text := resp.Content[0].TextContent.Text; !strings.HasSuffix(text, "sinceId -1.") {
+		t.Fatalf("Expected an empty collection to start from -1, got %q", text)
+	}
+
+	items = `[{"_id": 7, "title": "First", "link": "https://example.com"}]`
+	resp, err = poll(context.Background(), PollCollectionArgs{CollectionID: 1, SinceID: pollFromStart})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if text := resp.Content[0].TextContent.Text; !strings.HasPrefix(text, "Found 1 new bookmarks") || !strings.HasSuffix(text, "sinceId 7.") {
+		t.Errorf("Expected the first save to be reported, got %q", text)
+	}
+}
@@ -0,0 +1,265 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// raindropPageSize is the largest page size the Raindrop API accepts.
+const raindropPageSize = 50
+
+// raindropTypes are the content types Raindrop assigns to bookmarks.
+var raindropTypes = []string{"link", "article", "image", "video", "document", "audio"}
+
+// validateType checks that t is one of raindropTypes.
+func validateType(t string) error {
+	for _, allowed := range raindropTypes {
+		if t == allowed {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid type %q: must be one of %s", t, strings.Join(raindropTypes, ", "))
+}
+
+// listRaindrops pages through a collection and returns up to maxItems raindrops.
+// Extra query parameters (search, sort, ...) are passed through unchanged.
+func (r *RaindropClient) listRaindrops(ctx context.Context, collection int, params url.Values, maxItems int) ([]map[string]interface{}, error) {
+	var items []map[string]interface{}
+	err := r.eachRaindropPage(ctx, collection, params, maxItems, func(page []map[string]interface{}) error {
+		items = append(items, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// eachRaindropPage pages through a collection like listRaindrops, but hands each
+// page to fn as soon as it arrives instead of collecting them, so callers can
+// process large collections without holding every raindrop in memory. Paging
+// stops at maxItems raindrops or when fn returns an error.
+func (r *RaindropClient) eachRaindropPage(ctx context.Context, collection int, params url.Values, maxItems int, fn func(page []map[string]interface{}) error) error {
+	seen := 0
+
+	for page := 0; seen < maxItems; page++ {
+		query := url.Values{}
+		for key, values := range params {
+			query[key] = values
+		}
+		query.Set("page", strconv.Itoa(page))
+		query.Set("perpage", strconv.Itoa(raindropPageSize))
+
+		endpoint := fmt.Sprintf("/raindrops/%d?%s", collection, query.Encode())
+		result, err := r.MakeRequest(ctx, endpoint, "GET", nil)
+		if err != nil {
+			return err
+		}
+
+		pageItems, ok := result["items"].([]interface{})
+		if !ok {
+			return fmt.Errorf("unable to parse results")
+		}
+
+		var items []map[string]interface{}
+		for _, item := range pageItems {
+			if bookmark, ok := item.(map[string]interface{}); ok {
+				items = append(items, bookmark)
+				seen++
+				if seen >= maxItems {
+					break
+				}
+			}
+		}
+		if len(items) > 0 {
+			if err := fn(items); err != nil {
+				return err
+			}
+		}
+
+		if len(pageItems) < raindropPageSize {
+			break
+		}
+	}
+
+	return nil
+}
+
+// CountMatches returns how many raindrops in a collection match params (e.g. a
+// search) without transferring them: it asks for a single-item page and reads
+// the total count Raindrop reports alongside it.
+func (r *RaindropClient) CountMatches(ctx context.Context, collection int, params url.Values) (int, error) {
+	query := url.Values{}
+	for key, values := range params {
+		query[key] = values
+	}
+	query.Set("perpage", "1")
+	query.Del("page")
+
+	result, err := r.MakeRequest(ctx, fmt.Sprintf("/raindrops/%d?%s", collection, query.Encode()), "GET", nil)
+	if err != nil {
+		return 0, err
+	}
+	count, ok := result["count"].(float64)
+	if !ok {
+		return 0, fmt.Errorf("unable to parse count")
+	}
+	return int(count), nil
+}
+
+// stringField returns the string value stored under key, or "" if absent.
+func stringField(item map[string]interface{}, key string) string {
+	s, _ := item[key].(string)
+	return s
+}
+
+// intField returns the numeric value stored under key as an int, or 0 if absent.
+func intField(item map[string]interface{}, key string) int {
+	n, _ := item[key].(float64)
+	return int(n)
+}
+
+// collectionIDOf returns the ID of the collection a raindrop belongs to.
+func collectionIDOf(item map[string]interface{}) int {
+	if collection, ok := item["collection"].(map[string]interface{}); ok {
+		return intField(collection, "$id")
+	}
+	return intField(item, "collectionId")
+}
+
+// getRaindrop fetches a single raindrop by ID.
+func (r *RaindropClient) getRaindrop(ctx context.Context, id int) (map[string]interface{}, error) {
+	result, err := r.MakeRequest(ctx, fmt.Sprintf("/raindrop/%d", id), "GET", nil)
+	if err != nil {
+		return nil, err
+	}
+	item, ok := result["item"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unable to parse bookmark %d", id)
+	}
+	return item, nil
+}
+
+// updateRaindrop applies the given fields to a raindrop and returns the updated item.
+func (r *RaindropClient) updateRaindrop(ctx context.Context, id int, fields map[string]interface{}) (map[string]interface{}, error) {
+	result, err := r.MakeRequest(ctx, fmt.Sprintf("/raindrop/%d", id), "PUT", fields)
+	if err != nil {
+		return nil, err
+	}
+	item, _ := result["item"].(map[string]interface{})
+	return item, nil
+}
+
+// updateRaindrops applies the same fields to the given raindrops with Raindrop's
+// bulk update, in batches of raindropBatchSize, and returns how many Raindrop
+// reports as modified.
+func (r *RaindropClient) updateRaindrops(ctx context.Context, collection int, ids []int, fields map[string]interface{}) (int, error) {
+	modified := 0
+	for start := 0; start < len(ids); start += raindropBatchSize {
+		end := min(start+raindropBatchSize, len(ids))
+
+		body := map[string]interface{}{"ids": ids[start:end]}
+		for key, value := range fields {
+			body[key] = value
+		}
+		result, err := r.MakeRequest(ctx, fmt.Sprintf("/raindrops/%d", collection), "PUT", body)
+		if err != nil {
+			return modified, err
+		}
+		modified += intField(result, "modified")
+	}
+	return modified, nil
+}
+
+// updateRaindropsBulk is updateRaindrops with the outcome per raindrop: the IDs
+// of a batch Raindrop accepted succeed, and every ID of a failed batch fails
+// with that batch's error. With stopOnError, the batches after the first
+// failure are not sent and their IDs are Skipped. It also returns how many
+// raindrops Raindrop reports as modified, which can be fewer than succeeded.
+func (r *RaindropClient) updateRaindropsBulk(ctx context.Context, collection int, ids []int, fields map[string]interface{}, stopOnError bool) (BulkResult, int) {
+	result := newBulkResult(nil, nil)
+	modified := 0
+	for start := 0; start < len(ids); start += raindropBatchSize {
+		end := min(start+raindropBatchSize, len(ids))
+
+		count, err := r.updateRaindrops(ctx, collection, ids[start:end], fields)
+		modified += count
+		if err != nil {
+			for _, id := range ids[start:end] {
+				result.Failed = append(result.Failed, BulkFailure{ID: id, Err: err.Error()})
+			}
+			if stopOnError {
+				result.Skipped = append(result.Skipped, ids[end:]...)
+				break
+			}
+			continue
+		}
+		result.Succeeded = append(result.Succeeded, ids[start:end]...)
+	}
+	return result, modified
+}
+
+// suggestCollections returns the IDs of the collections Raindrop suggests for
+// a saved raindrop, best match first.
+func (r *RaindropClient) suggestCollections(ctx context.Context, id int) ([]int, error) {
+	result, err := r.MakeRequest(ctx, fmt.Sprintf("/raindrop/%d/suggest", id), "GET", nil)
+	if err != nil {
+		return nil, err
+	}
+	item, _ := result["item"].(map[string]interface{})
+	suggestions, _ := item["collections"].([]interface{})
+
+	var ids []int
+	for _, suggestion := range suggestions {
+		if collection, ok := suggestion.(map[string]interface{}); ok {
+			ids = append(ids, intField(collection, "$id"))
+		}
+	}
+	return ids, nil
+}
+
+// parseURL asks Raindrop to fetch and parse a URL without saving it.
+func (r *RaindropClient) parseURL(ctx context.Context, link string) (map[string]interface{}, error) {
+	params := url.Values{}
+	params.Set("url", link)
+	result, err := r.MakeRequest(ctx, "/import/url/parse?"+params.Encode(), "GET", nil)
+	if err != nil {
+		return nil, err
+	}
+	item, ok := result["item"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unable to parse metadata for %s", link)
+	}
+	return item, nil
+}
+
+// extractTags returns the tags of a raindrop as a string slice.
+func extractTags(bookmark map[string]interface{}) []string {
+	tagList := []string{}
+	if tags, ok := bookmark["tags"].([]interface{}); ok {
+		for _, t := range tags {
+			if tag, ok := t.(string); ok {
+				tagList = append(tagList, tag)
+			}
+		}
+	}
+	return tagList
+}
+
+// dateLayouts are the date formats accepted from tool arguments.
+var dateLayouts = []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02"}
+
+// parseDate parses a date given as RFC 3339 or YYYY-MM-DD (interpreted as UTC).
+func parseDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q: expected YYYY-MM-DD or RFC 3339", value)
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestParseDate(t *testing.T) {
+	tests := map[string]time.Time{
+		"2023-04-05":                time.Date(2023, 4, 5, 0, 0, 0, 0, time.UTC),
+		"2023-04-05T10:11:12":       time.Date(2023, 4, 5, 10, 11, 12, 0, time.UTC),
+		"2023-04-05T10:11:12+02:00": time.Date(2023, 4, 5, 8, 11, 12, 0, time.UTC),
+	}
+	for input, expected := range tests {
+		got, err := parseDate(input)
+		if err != nil {
+			t.Errorf("parseDate(%q) returned error: %v", input, err)
+			continue
+		}
+		if !got.Equal(expected) {
+			t.Errorf("parseDate(%q) = %v, expected %v", input, got, expected)
+		}
+	}
+
+	for _, input := range []string{"", "05/04/2023", "2023-13-01"} {
+		if _, err := parseDate(input); err == nil {
+			t.Errorf("Expected error for %q", input)
+		}
+	}
+}
+
+func TestValidateType(t *testing.T) {
+	for _, valid := range raindropTypes {
+		if err := validateType(valid); err != nil {
+			t.Errorf("Expected %q to be valid, got: %v", valid, err)
+		}
+	}
+	for _, invalid := range []string{"", "Article", "podcast"} {
+		if err := validateType(invalid); err == nil {
+			t.Errorf("Expected %q to be invalid", invalid)
+		}
+	}
+}
+
+func TestCountMatches(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/raindrops/-1" {
+			t.Errorf("Unexpected path: %s", r.URL.Path)
+		}
+		query := r.URL.Query()
+		if query.Get("perpage") != "1" || query.Has("page") {
+			t.Errorf("Expected a single-item request, got %s", r.URL.RawQuery)
+		}
+		if query.Get("search") != "#go" {
+			t.Errorf("Expected search to be passed through, got %q", query.Get("search"))
+		}
+		w.Write([]byte(`{"result": true, "items": [{"_id": 1}], "count": 321}`))
+	}))
+	defer server.Close()
+
+	client := &RaindropClient{Token: "test-token", BaseURL: server.URL}
+	params := url.Values{}
+	params.Set("search", "#go")
+	params.Set("page", "3")
+	params.Set("perpage", "50")
+
+	count, err := client.CountMatches(context.Background(), unsortedCollectionID, params)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if count != 321 {
+		t.Errorf("Expected count 321, got %d", count)
+	}
+	if params.Get("perpage") != "50" {
+		t.Errorf("Expected caller's params to be left alone, got %v", params)
+	}
+}
+
+func TestUpdateRaindropsBatches(t *testing.T) {
+	var batches []int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" || r.URL.Path != "/raindrops/0" {
+			t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		var body struct {
+			IDs       []int `json:"ids"`
+			Important bool  `json:"important"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("Unable to decode body: %v", err)
+		}
+		if !body.Important {
+			t.Errorf("Expected important to be set in every batch")
+		}
+		batches = append(batches, len(body.IDs))
+		fmt.Fprintf(w, `{"result": true, "modified": %d}`, len(body.IDs))
+	}))
+	defer server.Close()
+
+	ids := make([]int, 150)
+	for i := range ids {
+		ids[i] = i + 1
+	}
+
+	client := &RaindropClient{Token: "test-token", BaseURL: server.URL}
+	modified, err := client.updateRaindrops(context.Background(), 0, ids, map[string]interface{}{"important": true})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if modified != 150 {
+		t.Errorf("Expected 150 modified, got %d", modified)
+	}
+	if len(batches) != 2 || batches[0] != raindropBatchSize || batches[1] != 50 {
+		t.Errorf("Expected batches of 100 and 50, got %v", batches)
+	}
+}
+
+func TestUpdateRaindropsBulk(t *testing.T) {
+	batches := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		batches++
+		if batches == 2 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(`{"result": true}`))
+	}))
+	defer server.Close()
+
+	ids := make([]int, 3*raindropBatchSize)
+	for i := range ids {
+		ids[i] = i + 1
+	}
+
+	client := &RaindropClient{Token: "test-token", BaseURL: server.URL}
+	result, _ := client.updateRaindropsBulk(context.Background(), 0, ids, map[string]interface{}{"important": true}, true)
+	if batches != 2 {
+		t.Errorf("Expected the third batch not to be sent, got %d requests", batches)
+	}
+	if len(result.Succeeded) != raindropBatchSize || len(result.Failed) != raindropBatchSize || len(result.Skipped) != raindropBatchSize {
+		t.Errorf("Expected one batch each succeeded, failed and skipped, got %d, %d and %d",
+			len(result.Succeeded), len(result.Failed), len(result.Skipped))
+	}
+	if result.Failed[0].ID != raindropBatchSize+1 || result.Skipped[0] != 2*raindropBatchSize+1 {
+		t.Errorf("Expected the failed and skipped IDs of the later batches, got %+v", result)
+	}
+
+	batches = 0
+	result, _ = client.updateRaindropsBulk(context.Background(), 0, ids, map[string]interface{}{"important": true}, false)
+	if batches != 3 || len(result.Succeeded) != 2*raindropBatchSize || len(result.Failed) != raindropBatchSize {
+		t.Errorf("Expected best-effort to send every batch, got %d requests and %+v", batches, result)
+	}
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	mcp "github.com/metoro-io/mcp-golang"
+)
+
+const (
+	// wordsPerMinute is the reading speed reading-time assumes.
+	wordsPerMinute = 230
+	// defaultReadingMinutes is the estimate for a bookmark without readable text.
+	defaultReadingMinutes = 5
+	// maxCacheFetches caps how many permanent copies reading-time downloads per call.
+	maxCacheFetches = 100
+	// maxReadingTimeListed caps the per-bookmark breakdown.
+	maxReadingTimeListed = 50
+)
+
+// typeReadingMinutes overrides defaultReadingMinutes for content types that
+// are not read as text.
+var typeReadingMinutes = map[string]int{
+	"image": 1,
+	"video": 10,
+	"audio": 10,
+}
+
+// markupPattern matches HTML tags, along with script and style elements and their contents.
+var markupPattern = regexp.MustCompile(`(?is)<script.*?</script>|<style.*?</style>|<[^>]*>`)
+
+// htmlWordCount counts the words in the visible text of an HTML document.
+func htmlWordCount(document string) int {
+	return len(strings.Fields(html.UnescapeString(markupPattern.ReplaceAllString(document, " "))))
+}
+
+// minutesForWords is the reading time for a number of words, rounded up.
+func minutesForWords(words int) int {
+	return (words + wordsPerMinute - 1) / wordsPerMinute
+}
+
+// readingEstimate is the estimated reading time of one bookmark.
+type readingEstimate struct {
+	ID      int
+	Title   string
+	Minutes int
+	Source  string // "cached copy", "excerpt" or "default"
+}
+
+// estimateReading estimates a bookmark's reading time from the word count of
+// its cached copy when one was fetched (words > 0), and otherwise from a default
+// for its type, raised when the excerpt alone would take longer to read.
+func estimateReading(bookmark map[string]interface{}, words int) readingEstimate {
+	estimate := readingEstimate{ID: intField(bookmark, "_id"), Title: stringField(bookmark, "title")}
+	if words > 0 {
+		estimate.Minutes = max(minutesForWords(words), 1)
+		estimate.Source = "cached copy"
+		return estimate
+	}
+
+	estimate.Minutes = defaultReadingMinutes
+	if minutes, ok := typeReadingMinutes[stringField(bookmark, "type")]; ok {
+		estimate.Minutes = minutes
+	}
+	estimate.Source = "default"
+	if excerptMinutes := minutesForWords(len(strings.Fields(stringField(bookmark, "excerpt")))); excerptMinutes > estimate.Minutes {
+		estimate.Minutes = excerptMinutes
+		estimate.Source = "excerpt"
+	}
+	return estimate
+}
+
+// hasCachedCopy reports whether Raindrop holds a ready permanent copy of a bookmark.
+func hasCachedCopy(bookmark map[string]interface{}) bool {
+	cache, _ := bookmark["cache"].(map[string]interface{})
+	return stringField(cache, "status") == "ready"
+}
+
+type ReadingTimeArgs struct {
+	Collection int  `json:"collection" jsonschema:"description=Collection ID to estimate (0 for all bookmarks)"`
+	UseCache   bool `json:"useCache,omitempty" jsonschema:"description=Count the words of Raindrop's permanent copies where available (Pro; one extra request per bookmark, up to 100)"`
+}
+
+func readingTimeHandler(client *RaindropClient) func(ctx context.Context, args ReadingTimeArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args ReadingTimeArgs) (*mcp.ToolResponse, error) {
+		ctx, cancel := client.bulkContext(ctx)
+		defer cancel()
+
+		items, err := client.listRaindrops(ctx, args.Collection, url.Values{}, maxExportItems)
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+		if len(items) == 0 {
+			return mcp.NewToolResponse(
+				mcp.NewTextContent("The collection is empty."),
+			), nil
+		}
+
+		words := make([]int, len(items))
+		if args.UseCache {
+			var cached []int
+			for i, item := range items {
+				if hasCachedCopy(item) && len(cached) < maxCacheFetches {
+					cached = append(cached, i)
+				}
+			}
+			// A copy that cannot be fetched falls back to the default estimate.
+			client.forEachConcurrent(ctx, len(cached), func(ctx context.Context, n int) error {
+				i := cached[n]
+				_, body, err := client.doRequest(ctx, "GET", fmt.Sprintf("/raindrop/%d/cache", intField(items[i], "_id")), nil, nil)
+				if err == nil {
+					words[i] = htmlWordCount(string(body))
+				}
+				return err
+			})
+		}
+
+		estimates := make([]readingEstimate, len(items))
+		total := 0
+		sources := map[string]int{}
+		for i, item := range items {
+			estimates[i] = estimateReading(item, words[i])
+			total += estimates[i].Minutes
+			sources[estimates[i].Source]++
+		}
+		sort.SliceStable(estimates, func(i, j int) bool {
+			return estimates[i].Minutes > estimates[j].Minutes
+		})
+
+		var report strings.Builder
+		report.WriteString(fmt.Sprintf("Estimated reading time for %d bookmarks: %d minutes (%.1f hours)", len(items), total, float64(total)/60))
+		report.WriteString(fmt.Sprintf("\nBased on: %d cached copies, %d excerpts, %d defaults", sources["cached copy"], sources["excerpt"], sources["default"]))
+		report.WriteString("\n\nLongest reads:")
+		for _, estimate := range estimates[:min(len(estimates), maxReadingTimeListed)] {
+			report.WriteString(fmt.Sprintf("\n%d min — %s (ID %d, %s)", estimate.Minutes, estimate.Title, estimate.ID, estimate.Source))
+		}
+		if len(estimates) > maxReadingTimeListed {
+			report.WriteString(fmt.Sprintf("\n... and %d more", len(estimates)-maxReadingTimeListed))
+		}
+
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(report.String()),
+		), nil
+	}
+}
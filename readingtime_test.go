@@ -0,0 +1,36 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHTMLWordCount(t *testing.T) {
+	document := `<html><head><style>body { color: red }</style><script>var words = "not counted";</script></head>
+<body><h1>Three word title</h1><p>Caf&eacute; &amp; more<br/>text</p></body></html>`
+	if got := htmlWordCount(document); got != 7 {
+		t.Errorf("Expected 7 words, got %d", got)
+	}
+}
+
+func TestEstimateReading(t *testing.T) {
+	tests := []struct {
+		bookmark map[string]interface{}
+		words    int
+		minutes  int
+		source   string
+	}{
+		{map[string]interface{}{"type": "article"}, 1000, 5, "cached copy"},
+		{map[string]interface{}{"type": "article"}, 20, 1, "cached copy"},
+		{map[string]interface{}{"type": "article", "excerpt": "Short summary."}, 0, defaultReadingMinutes, "default"},
+		{map[string]interface{}{"type": "video"}, 0, 10, "default"},
+		{map[string]interface{}{"type": "link", "excerpt": strings.Repeat("word ", 1500)}, 0, 7, "excerpt"},
+	}
+	for _, test := range tests {
+		estimate := estimateReading(test.bookmark, test.words)
+		if estimate.Minutes != test.minutes || estimate.Source != test.source {
+			t.Errorf("estimateReading(%v, %d) = %d min from %s, expected %d min from %s",
+				stringField(test.bookmark, "type"), test.words, estimate.Minutes, estimate.Source, test.minutes, test.source)
+		}
+	}
+}
@@ -0,0 +1,236 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	mcp "github.com/metoro-io/mcp-golang"
+)
+
+// reminderProNote explains why a reminder may be missing.
+const reminderProNote = "Reminders require Raindrop Pro; on a free plan Raindrop does not return them."
+
+// bookmarkReminder is the reminder set on a raindrop.
+type bookmarkReminder struct {
+	Date time.Time
+	Note string
+}
+
+// reminderOf returns the reminder of a raindrop. ok is false when none is set
+// or its date cannot be read.
+func reminderOf(bookmark map[string]interface{}) (reminder bookmarkReminder, ok bool) {
+	fields, _ := bookmark["reminder"].(map[string]interface{})
+	date, err := parseDate(stringField(fields, "date"))
+	if err != nil {
+		return bookmarkReminder{}, false
+	}
+	return bookmarkReminder{Date: date, Note: stringField(fields, "note")}, true
+}
+
+// describeReminderDate renders a reminder date with how far it is from now,
+// e.g. "Tue, 5 Mar 2024 09:00 UTC (in 3 days)".
+func describeReminderDate(date, now time.Time) string {
+	formatted := date.UTC().Format("Mon, 2 Jan 2006 15:04 UTC")
+	days := int(date.Sub(now).Hours() / 24)
+	switch {
+	case date.Before(now):
+		return fmt.Sprintf("%s (overdue by %s)", formatted, pluralDays(-days))
+	case days == 0:
+		return formatted + " (within a day)"
+	default:
+		return fmt.Sprintf("%s (in %s)", formatted, pluralDays(days))
+	}
+}
+
+// pluralDays renders a whole number of days, with "less than a day" for 0.
+func pluralDays(days int) string {
+	switch days {
+	case 0:
+		return "less than a day"
+	case 1:
+		return "1 day"
+	default:
+		return fmt.Sprintf("%d days", days)
+	}
+}
+
+type GetReminderArgs struct {
+	ID int `json:"id" jsonschema:"required,description=Bookmark ID"`
+}
+
+func getReminderHandler(client *RaindropClient) func(ctx context.Context, args GetReminderArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args GetReminderArgs) (*mcp.ToolResponse, error) {
+		if args.ID == 0 {
+			return nil, fmt.Errorf("ID is required")
+		}
+
+		bookmark, err := client.getRaindrop(ctx, args.ID)
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+
+		reminder, ok := reminderOf(bookmark)
+		if !ok {
+			return mcp.NewToolResponse(
+				mcp.NewTextContent(fmt.Sprintf("Bookmark %d has no reminder. %s", args.ID, reminderProNote)),
+			), nil
+		}
+
+		var report strings.Builder
+		report.WriteString(fmt.Sprintf("Reminder for bookmark %d (%s):", args.ID, stringField(bookmark, "title")))
+		report.WriteString(fmt.Sprintf("\nDate: %s", describeReminderDate(reminder.Date, time.Now())))
+		if reminder.Note != "" {
+			report.WriteString(fmt.Sprintf("\nNote: %s", reminder.Note))
+		}
+
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(report.String()),
+		), nil
+	}
+}
+
+type ClearReminderArgs struct {
+	ID int `json:"id" jsonschema:"required,description=Bookmark ID"`
+}
+
+func clearReminderHandler(client *RaindropClient) func(ctx context.Context, args ClearReminderArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args ClearReminderArgs) (*mcp.ToolResponse, error) {
+		if args.ID <= 0 {
+			return nil, fmt.Errorf("ID is required")
+		}
+
+		bookmark, err := client.getRaindrop(ctx, args.ID)
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+		if _, ok := reminderOf(bookmark); !ok {
+			return mcp.NewToolResponse(
+				mcp.NewTextContent(fmt.Sprintf("Bookmark %d has no reminder to clear.", args.ID)),
+			), nil
+		}
+
+		// Raindrop removes the reminder when it is set to null.
+		updated, err := client.updateRaindrop(ctx, args.ID, map[string]interface{}{"reminder": nil})
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+		if _, ok := reminderOf(updated); ok {
+			return nil, fmt.Errorf("Raindrop kept the reminder on bookmark %d", args.ID)
+		}
+
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(fmt.Sprintf("Cleared the reminder on bookmark %d.", args.ID)),
+		), nil
+	}
+}
+
+// defaultReminderDays is how far ahead list-reminders looks by default.
+const defaultReminderDays = 7
+
+// reminderEntry is a bookmark together with its reminder.
+type reminderEntry struct {
+	Bookmark map[string]interface{}
+	Reminder bookmarkReminder
+}
+
+// upcomingReminders returns the bookmarks whose reminder is due before
+// now+within, overdue ones included, ordered by reminder date.
+func upcomingReminders(items []map[string]interface{}, now time.Time, within time.Duration) []reminderEntry {
+	var entries []reminderEntry
+	for _, item := range items {
+		reminder, ok := reminderOf(item)
+		if !ok || reminder.Date.After(now.Add(within)) {
+			continue
+		}
+		entries = append(entries, reminderEntry{Bookmark: item, Reminder: reminder})
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Reminder.Date.Before(entries[j].Reminder.Date)
+	})
+	return entries
+}
+
+// renderReminderAgenda renders reminders as a Markdown agenda with overdue
+// reminders first, then one section per day (UTC).
+func renderReminderAgenda(entries []reminderEntry, now time.Time) string {
+	var doc strings.Builder
+	doc.WriteString("# Reminders\n")
+	heading := ""
+	for _, entry := range entries {
+		day := entry.Reminder.Date.UTC().Format("Mon, 2 Jan 2006")
+		if entry.Reminder.Date.Before(now) {
+			day = "Overdue"
+		}
+		if day != heading {
+			doc.WriteString(fmt.Sprintf("\n## %s\n\n", day))
+			heading = day
+		}
+
+		line := strings.TrimSuffix(markdownLine(entry.Bookmark, false), "\n")
+		doc.WriteString(fmt.Sprintf("%s (%s)\n", line, entry.Reminder.Date.UTC().Format("15:04 UTC")))
+		if entry.Reminder.Note != "" {
+			doc.WriteString("  - " + markdownEscaper.Replace(entry.Reminder.Note) + "\n")
+		}
+	}
+	return doc.String()
+}
+
+type ListRemindersArgs struct {
+	WithinDays int  `json:"withinDays,omitempty" jsonschema:"description=List reminders due within this many days (default 7); overdue reminders are always included"`
+	Markdown   bool `json:"markdown,omitempty" jsonschema:"description=Return a Markdown agenda grouped by day"`
+}
+
+func listRemindersHandler(client *RaindropClient) func(ctx context.Context, args ListRemindersArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args ListRemindersArgs) (*mcp.ToolResponse, error) {
+		days := args.WithinDays
+		if days < 0 {
+			return nil, fmt.Errorf("withinDays must be positive")
+		}
+		if days == 0 {
+			days = defaultReminderDays
+		}
+
+		ctx, cancel := client.bulkContext(ctx)
+		defer cancel()
+
+		params := url.Values{}
+		params.Set("search", "reminder:true")
+		items, err := client.listRaindrops(ctx, 0, params, maxExportItems)
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+
+		now := time.Now()
+		entries := upcomingReminders(items, now, time.Duration(days)*24*time.Hour)
+		if len(entries) == 0 {
+			return mcp.NewToolResponse(
+				mcp.NewTextContent(fmt.Sprintf("No reminders due in the next %d days. %s", days, reminderProNote)),
+			), nil
+		}
+
+		if args.Markdown {
+			return mcp.NewToolResponse(
+				mcp.NewTextContent(renderReminderAgenda(entries, now)),
+			), nil
+		}
+
+		var formattedResults strings.Builder
+		for _, entry := range entries {
+			formattedResults.WriteString(fmt.Sprintf("\nID: %d\nTitle: %s\nURL: %s\nReminder: %s",
+				intField(entry.Bookmark, "_id"), stringField(entry.Bookmark, "title"), stringField(entry.Bookmark, "link"),
+				describeReminderDate(entry.Reminder.Date, now)))
+			if entry.Reminder.Note != "" {
+				formattedResults.WriteString(fmt.Sprintf("\nNote: %s", entry.Reminder.Note))
+			}
+			formattedResults.WriteString("\n---")
+		}
+
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(fmt.Sprintf("Found %d reminders due in the next %d days:%s", len(entries), days, formattedResults.String())),
+		), nil
+	}
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestReminderOf(t *testing.T) {
+	reminder, ok := reminderOf(map[string]interface{}{
+		"reminder": map[string]interface{}{"date": "2024-03-05T09:00:00.000Z", "note": "Read before the meeting"},
+	})
+	if !ok {
+		t.Fatal("Expected a reminder")
+	}
+	if !reminder.Date.Equal(time.Date(2024, 3, 5, 9, 0, 0, 0, time.UTC)) || reminder.Note != "Read before the meeting" {
+		t.Errorf("Unexpected reminder: %+v", reminder)
+	}
+
+	for _, bookmark := range []map[string]interface{}{
+		{},
+		{"reminder": map[string]interface{}{}},
+		{"reminder": map[string]interface{}{"date": ""}},
+	} {
+		if _, ok := reminderOf(bookmark); ok {
+			t.Errorf("Expected no reminder for %v", bookmark)
+		}
+	}
+}
+
+func TestDescribeReminderDate(t *testing.T) {
+	now := time.Date(2024, 3, 2, 9, 0, 0, 0, time.UTC)
+	tests := map[time.Time]string{
+		time.Date(2024, 3, 5, 9, 0, 0, 0, time.UTC):  "Tue, 5 Mar 2024 09:00 UTC (in 3 days)",
+		time.Date(2024, 3, 2, 18, 0, 0, 0, time.UTC): "Sat, 2 Mar 2024 18:00 UTC (within a day)",
+		time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC):  "Fri, 1 Mar 2024 09:00 UTC (overdue by 1 day)",
+		time.Date(2024, 3, 2, 8, 0, 0, 0, time.UTC):  "Sat, 2 Mar 2024 08:00 UTC (overdue by less than a day)",
+	}
+	for date, expected := range tests {
+		if got := describeReminderDate(date, now); got != expected {
+			t.Errorf("describeReminderDate(%v) = %q, expected %q", date, got, expected)
+		}
+	}
+}
+
+func TestClearReminder(t *testing.T) {
+	cleared := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			if cleared {
+				w.Write([]byte(`{"result": true, "item": {"_id": 7}}`))
+				return
+			}
+			w.Write([]byte(`{"result": true, "item": {"_id": 7, "reminder": {"date": "2024-03-05T09:00:00.000Z"}}}`))
+		case "PUT":
+			body, _ := io.ReadAll(r.Body)
+			if string(body) != `{"reminder":null}` {
+				t.Errorf("Expected reminder to be set to null, got %s", body)
+			}
+			cleared = true
+			w.Write([]byte(`{"result": true, "item": {"_id": 7}}`))
+		}
+	}))
+	defer server.Close()
+
+	client := &RaindropClient{Token: "test-token", BaseURL: server.URL}
+	handler := clearReminderHandler(client)
+
+	response, err := handler(context.Background(), ClearReminderArgs{ID: 7})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if text := response.Content[0].TextContent.Text; text != "Cleared the reminder on bookmark 7." {
+		t.Errorf("Unexpected response: %q", text)
+	}
+
+	response, err = handler(context.Background(), ClearReminderArgs{ID: 7})
+	if err != nil || response.Content[0].TextContent.Text != "Bookmark 7 has no reminder to clear." {
+		t.Errorf("Expected nothing to clear the second time, got %v", err)
+	}
+
+	if _, err := handler(context.Background(), ClearReminderArgs{}); err == nil {
+		t.Error("Expected error for a missing ID")
+	}
+}
+
+func TestUpcomingReminders(t *testing.T) {
+	now := time.Date(2024, 3, 2, 9, 0, 0, 0, time.UTC)
+	items := []map[string]interface{}{
+		{"_id": float64(1), "title": "Later", "link": "https://a.example", "reminder": map[string]interface{}{"date": "2024-03-20T09:00:00Z"}},
+		{"_id": float64(2), "title": "Soon", "link": "https://b.example", "reminder": map[string]interface{}{"date": "2024-03-04T10:30:00Z", "note": "Reply"}},
+		{"_id": float64(3), "title": "Missed", "link": "https://c.example", "reminder": map[string]interface{}{"date": "2024-02-28T08:00:00Z"}},
+		{"_id": float64(4), "title": "None", "link": "https://d.example"},
+	}
+
+	entries := upcomingReminders(items, now, 7*24*time.Hour)
+	if len(entries) != 2 || intField(entries[0].Bookmark, "_id") != 3 || intField(entries[1].Bookmark, "_id") != 2 {
+		t.Fatalf("Expected the overdue then the upcoming reminder, got %+v", entries)
+	}
+
+	expected := "# Reminders\n" +
+		"\n## Overdue\n\n- [Missed](https://c.example) (08:00 UTC)\n" +
+		"\n## Mon, 4 Mar 2024\n\n- [Soon](https://b.example) (10:30 UTC)\n  - Reply\n"
+	if agenda := renderReminderAgenda(entries, now); agenda != expected {
+		t.Errorf("Unexpected agenda:\n%s\nexpected:\n%s", agenda, expected)
+	}
+}
@@ -0,0 +1,18 @@
+package main
+
+import (
+	"encoding/json"
+
+	mcp "github.com/metoro-io/mcp-golang"
+)
+
+// jsonResponse returns v as pretty-printed JSON text content.
+func jsonResponse(v interface{}) (*mcp.ToolResponse, error) {
+	output, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return mcp.NewToolResponse(
+		mcp.NewTextContent(string(output)),
+	), nil
+}
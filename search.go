@@ -0,0 +1,408 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	mcp "github.com/metoro-io/mcp-golang"
+)
+
+const (
+	// defaultSearchResults is how many results paginated search tools return by default.
+	defaultSearchResults = 100
+	// maxSearchResults caps how many results paginated search tools return.
+	maxSearchResults = 500
+)
+
+// BuildSearchQuery turns structured search arguments into a Raindrop search
+// string and the query parameters to send with it. Arguments are assumed to
+// have passed validateSearchFilters; unparseable dates are skipped.
+func BuildSearchQuery(args SearchBookmarksArgs) (string, url.Values) {
+	var terms []string
+
+	if query := strings.TrimSpace(args.Query); query != "" {
+		terms = append(terms, query)
+	}
+	if phrase := strings.TrimSpace(args.Phrase); phrase != "" {
+		terms = append(terms, quoteSearchTerm(phrase))
+	}
+	for _, tag := range args.Tags {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			terms = append(terms, "#"+searchTerm(tag))
+		}
+	}
+	if args.Type != "" {
+		terms = append(terms, "type:"+args.Type)
+	}
+	if domain := strings.TrimSpace(args.Domain); domain != "" {
+		terms = append(terms, "site:"+domain)
+	}
+	if after, err := parseDate(args.CreatedAfter); err == nil {
+		terms = append(terms, "created:>"+after.Format("2006-01-02"))
+	}
+	if before, err := parseDate(args.CreatedBefore); err == nil {
+		terms = append(terms, "created:<"+before.Format("2006-01-02"))
+	}
+	if args.Important {
+		terms = append(terms, "❤️")
+	}
+	if args.NoTag {
+		terms = append(terms, "notag:true")
+	}
+	for _, word := range args.Exclude {
+		if word = strings.TrimSpace(word); word != "" {
+			terms = append(terms, "-"+searchTerm(word))
+		}
+	}
+	if args.TagsMatch == "any" && len(args.Tags) > 1 {
+		terms = append(terms, "match:OR")
+	}
+
+	query := strings.Join(terms, " ")
+	params := url.Values{}
+	params.Set("search", query)
+	return query, params
+}
+
+// searchTerm quotes a tag or word when it contains spaces.
+func searchTerm(term string) string {
+	if strings.ContainsAny(term, " \t") {
+		return quoteSearchTerm(term)
+	}
+	return term
+}
+
+// quoteSearchTerm wraps term in double quotes, dropping any quotes inside it.
+func quoteSearchTerm(term string) string {
+	return `"` + strings.ReplaceAll(term, `"`, "") + `"`
+}
+
+// validateSearchFilters checks that a search has at least one criterion and
+// that its enumerated and date fields are well formed.
+func validateSearchFilters(filters SearchFilters) error {
+	if filters.Type != "" {
+		if err := validateType(filters.Type); err != nil {
+			return err
+		}
+	}
+	if filters.TagsMatch != "" && filters.TagsMatch != "all" && filters.TagsMatch != "any" {
+		return fmt.Errorf("tagsMatch must be all or any")
+	}
+	for _, date := range []string{filters.CreatedAfter, filters.CreatedBefore} {
+		if date == "" {
+			continue
+		}
+		if _, err := parseDate(date); err != nil {
+			return err
+		}
+	}
+
+	if query, _ := BuildSearchQuery(SearchBookmarksArgs{SearchFilters: filters}); query == "" {
+		return fmt.Errorf("query or at least one filter is required")
+	}
+	return nil
+}
+
+// searchLimit clamps a caller supplied result limit to maxSearchResults.
+func searchLimit(maxResults int) int {
+	if maxResults <= 0 {
+		return defaultSearchResults
+	}
+	if maxResults > maxSearchResults {
+		return maxSearchResults
+	}
+	return maxResults
+}
+
+// clientSorts are the orderings search-bookmarks can apply after fetching a
+// page of results, for sorts Raindrop does not offer itself. Each reports
+// whether a sorts before b.
+var clientSorts = map[string]func(a, b map[string]interface{}) bool{
+	// Shortest titles first.
+	"title-length": func(a, b map[string]interface{}) bool {
+		return utf8.RuneCountInString(stringField(a, "title")) < utf8.RuneCountInString(stringField(b, "title"))
+	},
+	// Most tagged bookmarks first.
+	"tag-count": func(a, b map[string]interface{}) bool {
+		return len(extractTags(a)) > len(extractTags(b))
+	},
+	// Alphabetically by domain.
+	"domain": func(a, b map[string]interface{}) bool {
+		return strings.ToLower(stringField(a, "domain")) < strings.ToLower(stringField(b, "domain"))
+	},
+}
+
+// validateClientSort checks that name is one of clientSorts.
+func validateClientSort(name string) error {
+	if _, ok := clientSorts[name]; !ok {
+		return fmt.Errorf("invalid clientSort %q: must be one of title-length, tag-count, domain", name)
+	}
+	return nil
+}
+
+// sortBookmarks reorders search results with the named client sort. The sort
+// is stable, so Raindrop's order breaks ties.
+func sortBookmarks(items []map[string]interface{}, name string) {
+	less := clientSorts[name]
+	sort.SliceStable(items, func(i, j int) bool {
+		return less(items[i], items[j])
+	})
+}
+
+// formatBookmark renders a raindrop in the same layout search-bookmarks uses, prefixed by its ID.
+func formatBookmark(bookmark map[string]interface{}) string {
+	tagsStr := "No tags"
+	if tags := extractTags(bookmark); len(tags) > 0 {
+		tagsStr = strings.Join(tags, ", ")
+	}
+	return fmt.Sprintf("\nID: %d\nTitle: %s\nURL: %s\nTags: %s\n---",
+		intField(bookmark, "_id"), stringField(bookmark, "title"), stringField(bookmark, "link"), tagsStr)
+}
+
+// formatBookmarks renders a list of raindrops with formatBookmark.
+func formatBookmarks(items []map[string]interface{}) string {
+	var formatted strings.Builder
+	for _, item := range items {
+		formatted.WriteString(formatBookmark(item))
+	}
+	return formatted.String()
+}
+
+type SearchHelpArgs struct{}
+
+// searchHelpText documents the query syntax accepted by search-bookmarks.
+// Keep it in sync with the parameters SearchBookmarksArgs exposes.
+const searchHelpText = `Searching with search-bookmarks (and other tools taking the same filters)
+
+Structured parameters, combined with AND:
+  query               free text; raw operators below may be used here too
+  phrase              exact phrase
+  tags                tags the bookmark must have
+  tagsMatch           all (default) or any; any switches the search to match:OR
+  type                link | article | image | video | document | audio
+  domain              site the bookmark was saved from, e.g. github.com
+  createdAfter        saved after a day (YYYY-MM-DD)
+  createdBefore       saved before a day (YYYY-MM-DD)
+  important           favorites only
+  noTag               untagged bookmarks only
+  exclude             words that must not appear
+
+Raw Raindrop.io operators for the query text:
+  apple iphone        bookmarks containing all words
+  "exact phrase"      exact phrase match
+  -word               exclude bookmarks containing word
+  match:OR            match any term instead of all
+  #tag                tagged "tag" (use #"multi word" for spaces)
+  -#tag               exclude a tag
+  notag:true          without tags
+  type:article        content type
+  site:example.com    saved from a domain
+  created:2024-01-31  saved on a day
+  created:>2024-01-31 saved after a day
+  created:<2024-01-31 saved before a day
+  lastUpdate:>2024-01-31 modified after a day
+  ❤️                  favorites (important)
+  broken:true         broken links
+  duplicate:true      duplicate bookmarks
+  file:true           uploaded files
+  highlights:true     bookmarks with highlights
+  reminder:true       bookmarks with a reminder`
+
+func searchHelpHandler() func(args SearchHelpArgs) (*mcp.ToolResponse, error) {
+	return func(args SearchHelpArgs) (*mcp.ToolResponse, error) {
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(searchHelpText),
+		), nil
+	}
+}
+
+type ListURLsArgs struct {
+	SearchFilters
+	MaxResults int `json:"maxResults,omitempty" jsonschema:"description=Maximum number of URLs to return (default 100, cap 500)"`
+}
+
+func listURLsHandler(client *RaindropClient) func(ctx context.Context, args ListURLsArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args ListURLsArgs) (*mcp.ToolResponse, error) {
+		if err := validateSearchFilters(args.SearchFilters); err != nil {
+			return nil, err
+		}
+
+		_, params := BuildSearchQuery(SearchBookmarksArgs{SearchFilters: args.SearchFilters})
+		items, err := client.listRaindrops(ctx, 0, params, searchLimit(args.MaxResults))
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+
+		links := make([]string, 0, len(items))
+		for _, item := range items {
+			if link := stringField(item, "link"); link != "" {
+				links = append(links, link)
+			}
+		}
+
+		if len(links) == 0 {
+			return mcp.NewToolResponse(
+				mcp.NewTextContent("No bookmarks found matching your search."),
+			), nil
+		}
+
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(strings.Join(links, "\n")),
+		), nil
+	}
+}
+
+type BookmarksOnDateArgs struct {
+	Date       string `json:"date" jsonschema:"required,description=Day to look up as YYYY-MM-DD"`
+	Collection int    `json:"collection,omitempty" jsonschema:"description=Collection ID (0 for all bookmarks)"`
+	MaxResults int    `json:"maxResults,omitempty" jsonschema:"description=Maximum number of bookmarks to return (default 100, cap 500)"`
+}
+
+// dayRangeQuery returns a search matching bookmarks created on the given day,
+// expressed as exclusive bounds on the neighbouring days.
+func dayRangeQuery(day time.Time) string {
+	return fmt.Sprintf("created:>%s created:<%s", day.AddDate(0, 0, -1).Format("2006-01-02"), day.AddDate(0, 0, 1).Format("2006-01-02"))
+}
+
+func bookmarksOnDateHandler(client *RaindropClient) func(ctx context.Context, args BookmarksOnDateArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args BookmarksOnDateArgs) (*mcp.ToolResponse, error) {
+		day, err := parseDate(args.Date)
+		if err != nil {
+			return nil, err
+		}
+
+		params := url.Values{}
+		params.Set("search", dayRangeQuery(day))
+		items, err := client.listRaindrops(ctx, args.Collection, params, searchLimit(args.MaxResults))
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+
+		date := day.Format("2006-01-02")
+		if len(items) == 0 {
+			return mcp.NewToolResponse(
+				mcp.NewTextContent(fmt.Sprintf("No bookmarks were saved on %s.", date)),
+			), nil
+		}
+
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(fmt.Sprintf("Found %d bookmarks saved on %s:%s", len(items), date, formatBookmarks(items))),
+		), nil
+	}
+}
+
+// starConfirmThreshold is the number of matches above which star-search-results
+// asks for confirmation before changing anything.
+const starConfirmThreshold = 25
+
+type StarSearchResultsArgs struct {
+	SearchFilters
+	MaxResults int  `json:"maxResults,omitempty" jsonschema:"description=Maximum number of bookmarks to star (default 100, cap 500)"`
+	Confirm    bool `json:"confirm,omitempty" jsonschema:"description=Star the matches even when there are more than 25"`
+}
+
+func starSearchResultsHandler(client *RaindropClient) func(ctx context.Context, args StarSearchResultsArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args StarSearchResultsArgs) (*mcp.ToolResponse, error) {
+		if err := validateSearchFilters(args.SearchFilters); err != nil {
+			return nil, err
+		}
+
+		ctx, cancel := client.bulkContext(ctx)
+		defer cancel()
+
+		_, params := BuildSearchQuery(SearchBookmarksArgs{SearchFilters: args.SearchFilters})
+		items, err := client.listRaindrops(ctx, 0, params, searchLimit(args.MaxResults))
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+
+		var ids []int
+		for _, item := range items {
+			if item["important"] != true {
+				ids = append(ids, intField(item, "_id"))
+			}
+		}
+		already := len(items) - len(ids)
+
+		if len(ids) == 0 {
+			return mcp.NewToolResponse(
+				mcp.NewTextContent(fmt.Sprintf("Nothing to star: %d bookmarks matched, %d already favorites.", len(items), already)),
+			), nil
+		}
+		if len(ids) > starConfirmThreshold && !args.Confirm {
+			return mcp.NewToolResponse(
+				mcp.NewTextContent(fmt.Sprintf("%d bookmarks would be starred. Nothing was changed; run again with confirm set to star them.", len(ids))),
+			), nil
+		}
+
+		modified, err := client.updateRaindrops(ctx, 0, ids, map[string]interface{}{"important": true})
+		if err != nil {
+			return nil, fmt.Errorf("internal error after starring %d bookmarks: %v", modified, err)
+		}
+
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(fmt.Sprintf("Starred %d bookmarks (%d matched, %d already favorites).", modified, len(items), already)),
+		), nil
+	}
+}
+
+type ClearFavoritesArgs struct {
+	Collection int  `json:"collection" jsonschema:"description=Collection ID to clear (0 for all bookmarks, -1 for Unsorted)"`
+	Confirm    bool `json:"confirm" jsonschema:"required,description=Must be true to unstar the favorites"`
+}
+
+func clearFavoritesHandler(client *RaindropClient) func(ctx context.Context, args ClearFavoritesArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args ClearFavoritesArgs) (*mcp.ToolResponse, error) {
+		if args.Collection < unsortedCollectionID {
+			return nil, fmt.Errorf("invalid collection %d: use 0 for all bookmarks, -1 for Unsorted or a collection ID", args.Collection)
+		}
+
+		ctx, cancel := client.bulkContext(ctx)
+		defer cancel()
+
+		if args.Collection > 0 {
+			if _, err := client.getCollection(ctx, args.Collection); err != nil {
+				return nil, fmt.Errorf("internal error: %v", err)
+			}
+		}
+
+		params := url.Values{}
+		params.Set("search", "❤️")
+		items, err := client.listRaindrops(ctx, args.Collection, params, maxExportItems)
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+
+		var ids []int
+		for _, item := range items {
+			if item["important"] == true {
+				ids = append(ids, intField(item, "_id"))
+			}
+		}
+
+		if len(ids) == 0 {
+			return mcp.NewToolResponse(
+				mcp.NewTextContent("No favorites to clear."),
+			), nil
+		}
+		if !args.Confirm {
+			return mcp.NewToolResponse(
+				mcp.NewTextContent(fmt.Sprintf("%d favorites would be unstarred. Nothing was changed; run again with confirm set to clear them.", len(ids))),
+			), nil
+		}
+
+		modified, err := client.updateRaindrops(ctx, args.Collection, ids, map[string]interface{}{"important": false})
+		if err != nil {
+			return nil, fmt.Errorf("internal error after clearing %d favorites: %v", modified, err)
+		}
+
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(fmt.Sprintf("Cleared %d favorites.", modified)),
+		), nil
+	}
+}
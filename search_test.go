@@ -0,0 +1,119 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestBuildSearchQuery(t *testing.T) {
+	tests := []struct {
+		name     string
+		filters  SearchFilters
+		expected string
+	}{
+		{name: "text only", filters: SearchFilters{Query: "golang"}, expected: "golang"},
+		{name: "tags", filters: SearchFilters{Query: "golang", Tags: []string{"go", "web dev"}}, expected: `golang #go #"web dev"`},
+		{name: "tags any", filters: SearchFilters{Tags: []string{"go", "rust"}, TagsMatch: "any"}, expected: "#go #rust match:OR"},
+		{name: "single tag any", filters: SearchFilters{Tags: []string{"go"}, TagsMatch: "any"}, expected: "#go"},
+		{name: "type and domain", filters: SearchFilters{Type: "video", Domain: "youtube.com"}, expected: "type:video site:youtube.com"},
+		{name: "dates", filters: SearchFilters{CreatedAfter: "2024-01-01", CreatedBefore: "2024-02-01"}, expected: "created:>2024-01-01 created:<2024-02-01"},
+		{name: "flags", filters: SearchFilters{Important: true, NoTag: true}, expected: "❤️ notag:true"},
+		{name: "phrase", filters: SearchFilters{Phrase: `say "hello" world`}, expected: `"say hello world"`},
+		{name: "exclusions", filters: SearchFilters{Query: "go", Exclude: []string{"java", "spring boot", " "}}, expected: `go -java -"spring boot"`},
+		{name: "empty", filters: SearchFilters{}, expected: ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			query, params := BuildSearchQuery(SearchBookmarksArgs{SearchFilters: test.filters})
+			if query != test.expected {
+				t.Errorf("Expected query %q, got %q", test.expected, query)
+			}
+			if params.Get("search") != test.expected {
+				t.Errorf("Expected search parameter %q, got %q", test.expected, params.Get("search"))
+			}
+		})
+	}
+}
+
+func TestValidateSearchFilters(t *testing.T) {
+	valid := []SearchFilters{
+		{Query: "go"},
+		{Tags: []string{"go"}, TagsMatch: "any"},
+		{NoTag: true},
+		{CreatedAfter: "2024-01-01"},
+	}
+	for _, filters := range valid {
+		if err := validateSearchFilters(filters); err != nil {
+			t.Errorf("Expected %+v to be valid, got: %v", filters, err)
+		}
+	}
+
+	invalid := []SearchFilters{
+		{},
+		{Query: "go", Type: "podcast"},
+		{Query: "go", TagsMatch: "some"},
+		{CreatedBefore: "yesterday"},
+	}
+	for _, filters := range invalid {
+		if err := validateSearchFilters(filters); err == nil {
+			t.Errorf("Expected %+v to be invalid", filters)
+		}
+	}
+}
+
+func TestSearchLimit(t *testing.T) {
+	tests := map[int]int{0: defaultSearchResults, 20: 20, maxSearchResults + 1: maxSearchResults}
+	for input, expected := range tests {
+		if got := searchLimit(input); got != expected {
+			t.Errorf("searchLimit(%d) = %d, expected %d", input, got, expected)
+		}
+	}
+}
+
+func TestDayRangeQuery(t *testing.T) {
+	got := dayRangeQuery(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+	if got != "created:>2024-02-29 created:<2024-03-02" {
+		t.Errorf("Unexpected query: %s", got)
+	}
+}
+
+func TestFormatBookmark(t *testing.T) {
+	bookmark := map[string]interface{}{"_id": float64(3), "title": "Go", "link": "https://go.dev", "tags": []interface{}{"go"}}
+	expected := "\nID: 3\nTitle: Go\nURL: https://go.dev\nTags: go\n---"
+	if got := formatBookmark(bookmark); got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+}
+
+func TestSortBookmarks(t *testing.T) {
+	items := []map[string]interface{}{
+		{"_id": float64(1), "title": "Medium title", "domain": "b.example", "tags": []interface{}{"a"}},
+		{"_id": float64(2), "title": "A much longer title", "domain": "A.example", "tags": []interface{}{"a", "b", "c"}},
+		{"_id": float64(3), "title": "Short", "domain": "c.example"},
+		{"_id": float64(4), "title": "Tiny", "domain": "b.example", "tags": []interface{}{"a"}},
+	}
+
+	tests := map[string][]int{
+		"title-length": {4, 3, 1, 2},
+		"tag-count":    {2, 1, 4, 3},
+		"domain":       {2, 1, 4, 3},
+	}
+	for name, expected := range tests {
+		sorted := append([]map[string]interface{}{}, items...)
+		sortBookmarks(sorted, name)
+
+		var ids []int
+		for _, item := range sorted {
+			ids = append(ids, intField(item, "_id"))
+		}
+		if !reflect.DeepEqual(ids, expected) {
+			t.Errorf("sortBookmarks(%s) = %v, expected %v", name, ids, expected)
+		}
+	}
+
+	if err := validateClientSort("random"); err == nil {
+		t.Error("Expected error for unknown client sort")
+	}
+}
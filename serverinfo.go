@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	mcp "github.com/metoro-io/mcp-golang"
+)
+
+// version is set at build time by goreleaser through -X main.version.
+var version = "dev"
+
+// serverTransport names the transport the server is served over.
+const serverTransport = "stdio"
+
+// toolRegistry registers tools on the MCP server and remembers their names,
+// so server-info can list them. It is safe for concurrent use.
+type toolRegistry struct {
+	server *mcp.Server
+
+	mu    sync.Mutex
+	names []string
+}
+
+// register registers a tool on the server and records its name on success.
+func (t *toolRegistry) register(name string, description string, handler interface{}) error {
+	if err := t.server.RegisterTool(name, description, handler); err != nil {
+		return err
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.names = append(t.names, name)
+	return nil
+}
+
+// registered returns a copy of the names of the tools registered so far.
+func (t *toolRegistry) registered() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]string{}, t.names...)
+}
+
+// serverInfo is the configuration reported by server-info. It must never
+// include the API token.
+type serverInfo struct {
+	Version        string   `json:"version"`
+	Transport      string   `json:"transport"`
+	Timeout        string   `json:"timeout"`
+	BulkTimeout    string   `json:"bulkTimeout"`
+	Concurrency    int      `json:"concurrency"`
+	DefaultTags    []string `json:"defaultTags"`
+	StripTracking  bool     `json:"stripTracking"`
+	IdempotencyTTL string   `json:"idempotencyTtl"`
+	// RateLimit is the last quota Raindrop reported, or nil before any request.
+	RateLimit *rateLimitInfo `json:"rateLimit"`
+	Tools     []string       `json:"tools"`
+}
+
+// rateLimitInfo is the JSON form of a RateLimit.
+type rateLimitInfo struct {
+	Limit     int       `json:"limit"`
+	Remaining int       `json:"remaining"`
+	Reset     time.Time `json:"reset"`
+}
+
+// durationOr formats d, or fallback when d is unset.
+func durationOr(d, fallback time.Duration) string {
+	if d <= 0 {
+		d = fallback
+	}
+	return d.String()
+}
+
+// describeServer collects the server's runtime configuration.
+func describeServer(client *RaindropClient, tools []string) serverInfo {
+	concurrency := client.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+
+	info := serverInfo{
+		Version:        version,
+		Transport:      serverTransport,
+		Timeout:        durationOr(client.Timeout, DefaultTimeout),
+		BulkTimeout:    durationOr(client.BulkTimeout, DefaultBulkTimeout),
+		Concurrency:    concurrency,
+		DefaultTags:    mergeTags(client.DefaultTags),
+		StripTracking:  client.StripTracking,
+		IdempotencyTTL: durationOr(client.IdempotencyTTL, DefaultIdempotencyTTL),
+		Tools:          append([]string{}, tools...),
+	}
+	if rateLimit := client.RateLimit(); rateLimit.Seen {
+		info.RateLimit = &rateLimitInfo{Limit: rateLimit.Limit, Remaining: rateLimit.Remaining, Reset: rateLimit.Reset}
+	}
+	return info
+}
+
+type ServerInfoArgs struct{}
+
+func serverInfoHandler(client *RaindropClient, tools *toolRegistry) func(ctx context.Context, args ServerInfoArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args ServerInfoArgs) (*mcp.ToolResponse, error) {
+		return jsonResponse(describeServer(client, tools.registered()))
+	}
+}
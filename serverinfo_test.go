@@ -0,0 +1,38 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDescribeServer(t *testing.T) {
+	client := &RaindropClient{Token: "secret-token", Concurrency: 8, DefaultTags: []string{"agent"}, Timeout: 45 * time.Second}
+
+	info := describeServer(client, []string{"create-bookmark", "server-info"})
+	if info.Version != version || info.Transport != "stdio" || info.Concurrency != 8 || info.Timeout != "45s" || info.BulkTimeout != "5m0s" {
+		t.Errorf("Unexpected info: %+v", info)
+	}
+	if !reflect.DeepEqual(info.Tools, []string{"create-bookmark", "server-info"}) {
+		t.Errorf("Unexpected tools: %v", info.Tools)
+	}
+	if info.RateLimit != nil {
+		t.Errorf("Expected no rate limit before any request, got %+v", info.RateLimit)
+	}
+
+	client.recordRateLimit(http.Header{"X-Ratelimit-Limit": {"120"}, "X-Ratelimit-Remaining": {"7"}})
+	if info := describeServer(client, nil); info.RateLimit == nil || info.RateLimit.Remaining != 7 {
+		t.Errorf("Expected observed rate limit, got %+v", info.RateLimit)
+	}
+
+	output, err := json.Marshal(describeServer(client, nil))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if strings.Contains(string(output), "secret-token") {
+		t.Errorf("server-info must not include the token: %s", output)
+	}
+}
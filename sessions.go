@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	mcp "github.com/metoro-io/mcp-golang"
+)
+
+// sessionTagPrefix marks bookmarks saved as part of a browsing session.
+const sessionTagPrefix = "session:"
+
+// sessionTag returns the tag shared by every bookmark of a session.
+func sessionTag(name string) string {
+	return sessionTagPrefix + strings.TrimSpace(name)
+}
+
+type SaveSessionArgs struct {
+	URLs        []string `json:"urls" jsonschema:"required,description=URLs of the open tabs"`
+	SessionName string   `json:"sessionName" jsonschema:"required,description=Name to save the session under"`
+	Collection  int      `json:"collection,omitempty" jsonschema:"description=Collection ID for the bookmarks"`
+	JSON        bool     `json:"json,omitempty" jsonschema:"description=Return the result as JSON"`
+}
+
+func saveSessionHandler(client *RaindropClient) func(ctx context.Context, args SaveSessionArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args SaveSessionArgs) (*mcp.ToolResponse, error) {
+		if strings.TrimSpace(args.SessionName) == "" {
+			return nil, fmt.Errorf("session name is required")
+		}
+		urls := dedupeStrings(args.URLs)
+		if len(urls) == 0 {
+			return nil, fmt.Errorf("at least one URL is required")
+		}
+
+		tag := sessionTag(args.SessionName)
+		savedAt := tag + "@" + time.Now().UTC().Format("2006-01-02T15:04Z")
+
+		var invalid []BulkFailure
+		var items []map[string]interface{}
+		for _, link := range urls {
+			if err := validateURL(link); err != nil {
+				invalid = append(invalid, BulkFailure{Item: link, Err: err.Error()})
+				continue
+			}
+			items = append(items, map[string]interface{}{
+				"link":       link,
+				"tags":       []string{tag, savedAt},
+				"collection": map[string]interface{}{"$id": args.Collection},
+			})
+		}
+
+		ctx, cancel := client.bulkContext(ctx)
+		defer cancel()
+
+		created, result := client.createRaindrops(ctx, items, false)
+		result.Failed = append(invalid, result.Failed...)
+
+		if args.JSON {
+			return jsonResponse(map[string]interface{}{
+				"tag":     tag,
+				"savedAt": savedAt,
+				"result":  result,
+			})
+		}
+
+		responseText := fmt.Sprintf("Saved %d of %d tabs as session %q with tag %q (and %q). Reopen them with list-session.%s",
+			len(created), len(urls), strings.TrimSpace(args.SessionName), tag, savedAt, result.FailedText())
+
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(responseText),
+		), nil
+	}
+}
+
+type ListSessionArgs struct {
+	SessionName string `json:"sessionName" jsonschema:"required,description=Name the session was saved under"`
+}
+
+// sessionLinks returns the links of items carrying tag, in order.
+func sessionLinks(items []map[string]interface{}, tag string) []string {
+	var links []string
+	for _, item := range items {
+		if hasTag(item, tag) {
+			links = append(links, stringField(item, "link"))
+		}
+	}
+	return links
+}
+
+func listSessionHandler(client *RaindropClient) func(ctx context.Context, args ListSessionArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args ListSessionArgs) (*mcp.ToolResponse, error) {
+		if strings.TrimSpace(args.SessionName) == "" {
+			return nil, fmt.Errorf("session name is required")
+		}
+		tag := sessionTag(args.SessionName)
+
+		params := url.Values{}
+		params.Set("search", "#"+searchTerm(tag))
+		params.Set("sort", "created")
+
+		ctx, cancel := client.bulkContext(ctx)
+		defer cancel()
+
+		items, err := client.listRaindrops(ctx, 0, params, maxExportItems)
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+
+		links := sessionLinks(items, tag)
+		if len(links) == 0 {
+			return mcp.NewToolResponse(
+				mcp.NewTextContent(fmt.Sprintf("No such session: %q", strings.TrimSpace(args.SessionName))),
+			), nil
+		}
+
+		responseText := fmt.Sprintf("Session %q has %d URLs:\n\n%s\n", strings.TrimSpace(args.SessionName), len(links), strings.Join(links, "\n"))
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(responseText),
+		), nil
+	}
+}
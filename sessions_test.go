@@ -0,0 +1,20 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSessionLinks(t *testing.T) {
+	items := []map[string]interface{}{
+		{"link": "https://a.example", "tags": []interface{}{"session:Reading", "session:Reading@2024-05-01T10:00Z"}},
+		{"link": "https://b.example", "tags": []interface{}{"session:reading list"}},
+		{"link": "https://c.example", "tags": []interface{}{"Session:reading"}},
+	}
+
+	links := sessionLinks(items, sessionTag(" Reading "))
+	expected := []string{"https://a.example", "https://c.example"}
+	if !reflect.DeepEqual(links, expected) {
+		t.Errorf("Expected %v, got %v", expected, links)
+	}
+}
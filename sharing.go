@@ -0,0 +1,174 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	mcp "github.com/metoro-io/mcp-golang"
+)
+
+// ownerAccessLevel is the access level Raindrop reports on collections the user owns.
+const ownerAccessLevel = 4
+
+// accessLevelNames are the roles behind Raindrop's collection access levels.
+var accessLevelNames = map[int]string{
+	1: "read only",
+	2: "viewer",
+	3: "member",
+	4: "owner",
+}
+
+// accessLevelOf returns the user's access level on a collection, assuming
+// ownership when Raindrop omits it.
+func accessLevelOf(collection map[string]interface{}) int {
+	access, _ := collection["access"].(map[string]interface{})
+	if level := intField(access, "level"); level > 0 {
+		return level
+	}
+	return ownerAccessLevel
+}
+
+// accessRole returns the role name for an access level.
+func accessRole(level int) string {
+	if name, ok := accessLevelNames[level]; ok {
+		return name
+	}
+	return fmt.Sprintf("level %d", level)
+}
+
+// isShared reports whether a collection has collaborators or was shared with the user.
+func isShared(collection map[string]interface{}) bool {
+	_, hasCollaborators := collection["collaborators"]
+	return hasCollaborators || accessLevelOf(collection) < ownerAccessLevel
+}
+
+// fetchCollaborators returns the users a collection is shared with.
+func (r *RaindropClient) fetchCollaborators(ctx context.Context, id int) ([]map[string]interface{}, error) {
+	result, err := r.MakeRequest(ctx, fmt.Sprintf("/collection/%d/sharing", id), "GET", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	items, ok := result["items"].([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unable to parse collaborators of collection %d", id)
+	}
+
+	var collaborators []map[string]interface{}
+	for _, item := range items {
+		if collaborator, ok := item.(map[string]interface{}); ok {
+			collaborators = append(collaborators, collaborator)
+		}
+	}
+	return collaborators, nil
+}
+
+type ListSharedArgs struct{}
+
+func listSharedHandler(client *RaindropClient) func(ctx context.Context, args ListSharedArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args ListSharedArgs) (*mcp.ToolResponse, error) {
+		ctx, cancel := client.bulkContext(ctx)
+		defer cancel()
+
+		collections, err := client.fetchCollections(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+
+		var shared []int
+		for id, collection := range collections {
+			if isShared(collection) {
+				shared = append(shared, id)
+			}
+		}
+		sort.Ints(shared)
+
+		if len(shared) == 0 {
+			return mcp.NewToolResponse(
+				mcp.NewTextContent("None of your collections are shared. Collaborators can be invited from the Raindrop app; some sharing features require Raindrop Pro."),
+			), nil
+		}
+
+		counts := make([]int, len(shared))
+		errs := client.forEachConcurrent(ctx, len(shared), func(ctx context.Context, i int) error {
+			collaborators, err := client.fetchCollaborators(ctx, shared[i])
+			counts[i] = len(collaborators)
+			return err
+		})
+
+		var formattedResults strings.Builder
+		for i, id := range shared {
+			collection := collections[id]
+			collaborators := fmt.Sprintf("%d", counts[i])
+			if errs[i] != nil {
+				collaborators = fmt.Sprintf("unknown (%v)", errs[i])
+			}
+			formattedResults.WriteString(fmt.Sprintf("\nID: %d\nTitle: %s\nYour role: %s\nCollaborators: %s\n---",
+				id, strings.Join(collectionPath(collections, id), " > "), accessRole(accessLevelOf(collection)), collaborators))
+		}
+
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(fmt.Sprintf("Found %d shared collections:%s", len(shared), formattedResults.String())),
+		), nil
+	}
+}
+
+// minEditAccessLevel is the lowest access level that may change a collection's bookmarks.
+const minEditAccessLevel = 3
+
+// collectionAccess is what get-collection-access reports about a collection.
+type collectionAccess struct {
+	ID        int    `json:"id"`
+	Title     string `json:"title"`
+	Level     int    `json:"level"`
+	Role      string `json:"role"`
+	CanEdit   bool   `json:"canEdit"`
+	Draggable bool   `json:"draggable"`
+	OwnerID   int    `json:"ownerId,omitempty"`
+	OwnerName string `json:"ownerName,omitempty"`
+	Note      string `json:"note,omitempty"`
+}
+
+// accessOf summarizes the user's access to a collection record.
+func accessOf(collection map[string]interface{}) collectionAccess {
+	access, _ := collection["access"].(map[string]interface{})
+	owner, _ := collection["user"].(map[string]interface{})
+	creator, _ := collection["creatorRef"].(map[string]interface{})
+
+	level := accessLevelOf(collection)
+	result := collectionAccess{
+		ID:        intField(collection, "_id"),
+		Title:     stringField(collection, "title"),
+		Level:     level,
+		Role:      accessRole(level),
+		CanEdit:   level >= minEditAccessLevel,
+		Draggable: access["draggable"] == true,
+		OwnerID:   intField(owner, "$id"),
+		OwnerName: stringField(creator, "fullName"),
+	}
+	if !result.CanEdit {
+		result.Note = "read-only for you: do not create, update, move or delete bookmarks in this collection"
+	}
+	return result
+}
+
+type GetCollectionAccessArgs struct {
+	ID int `json:"id" jsonschema:"required,description=Collection ID"`
+}
+
+func getCollectionAccessHandler(client *RaindropClient) func(ctx context.Context, args GetCollectionAccessArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args GetCollectionAccessArgs) (*mcp.ToolResponse, error) {
+		if args.ID <= 0 {
+			return nil, fmt.Errorf("a collection ID is required; system collections always belong to you")
+		}
+
+		collection, err := client.getCollection(ctx, args.ID)
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+
+		return jsonResponse(accessOf(collection))
+	}
+}
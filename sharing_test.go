@@ -0,0 +1,47 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestIsShared(t *testing.T) {
+	tests := []struct {
+		collection map[string]interface{}
+		shared     bool
+		role       string
+	}{
+		{map[string]interface{}{"title": "Private"}, false, "owner"},
+		{map[string]interface{}{"access": map[string]interface{}{"level": float64(4)}, "collaborators": map[string]interface{}{"$id": "abc"}}, true, "owner"},
+		{map[string]interface{}{"access": map[string]interface{}{"level": float64(3)}}, true, "member"},
+		{map[string]interface{}{"access": map[string]interface{}{"level": float64(2)}}, true, "viewer"},
+	}
+	for _, test := range tests {
+		if got := isShared(test.collection); got != test.shared {
+			t.Errorf("isShared(%v) = %v, expected %v", test.collection, got, test.shared)
+		}
+		if got := accessRole(accessLevelOf(test.collection)); got != test.role {
+			t.Errorf("role of %v = %q, expected %q", test.collection, got, test.role)
+		}
+	}
+}
+
+func TestAccessOf(t *testing.T) {
+	viewer := accessOf(map[string]interface{}{
+		"_id":        float64(12),
+		"title":      "Team reading",
+		"access":     map[string]interface{}{"level": float64(2), "draggable": false},
+		"user":       map[string]interface{}{"$id": float64(99)},
+		"creatorRef": map[string]interface{}{"fullName": "Sam Lee"},
+	})
+	if viewer.CanEdit || viewer.Role != "viewer" || viewer.Note == "" {
+		t.Errorf("Expected a read-only viewer, got %+v", viewer)
+	}
+	if viewer.ID != 12 || viewer.OwnerID != 99 || viewer.OwnerName != "Sam Lee" {
+		t.Errorf("Unexpected collection or owner details: %+v", viewer)
+	}
+
+	own := accessOf(map[string]interface{}{"_id": float64(5), "access": map[string]interface{}{"level": float64(4), "draggable": true}})
+	if !own.CanEdit || !own.Draggable || own.Note != "" {
+		t.Errorf("Expected an editable owned collection, got %+v", own)
+	}
+}
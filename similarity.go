@@ -0,0 +1,191 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode"
+
+	mcp "github.com/metoro-io/mcp-golang"
+)
+
+// defaultSimilarityThreshold is the similarity at which find-similar-titles groups titles.
+const defaultSimilarityThreshold = 0.8
+
+// titleSimilarities are the metrics find-similar-titles supports. Each returns
+// a score between 0 (unrelated) and 1 (identical) for two normalized titles.
+var titleSimilarities = map[string]func(a, b string) float64{
+	"jaccard":     jaccardSimilarity,
+	"levenshtein": levenshteinSimilarity,
+}
+
+// normalizeTitle lowercases a title and reduces punctuation and spacing to single spaces.
+func normalizeTitle(title string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	}), " ")
+}
+
+// jaccardSimilarity compares the sets of words in a and b.
+func jaccardSimilarity(a, b string) float64 {
+	wordsA, wordsB := map[string]bool{}, map[string]bool{}
+	for _, w := range strings.Fields(a) {
+		wordsA[w] = true
+	}
+	for _, w := range strings.Fields(b) {
+		wordsB[w] = true
+	}
+	if len(wordsA) == 0 && len(wordsB) == 0 {
+		return 1
+	}
+
+	shared := 0
+	for w := range wordsA {
+		if wordsB[w] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(wordsA)+len(wordsB)-shared)
+}
+
+// levenshteinSimilarity is one minus the edit distance between a and b,
+// relative to the length of the longer string.
+func levenshteinSimilarity(a, b string) float64 {
+	longest := max(len([]rune(a)), len([]rune(b)))
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(levenshteinDistance(a, b))/float64(longest)
+}
+
+// levenshteinDistance is the number of single-rune insertions, deletions and
+// substitutions that turn a into b.
+func levenshteinDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+// groupSimilarTitles groups the indexes of titles whose similarity reaches
+// threshold, directly or through other titles in the group. Only groups of two
+// or more are returned, in order of their first member.
+func groupSimilarTitles(titles []string, similarity func(a, b string) float64, threshold float64) [][]int {
+	parent := make([]int, len(titles))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(i int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	for i := range titles {
+		if titles[i] == "" {
+			continue
+		}
+		for j := i + 1; j < len(titles); j++ {
+			if titles[j] == "" || find(i) == find(j) {
+				continue
+			}
+			if similarity(titles[i], titles[j]) >= threshold {
+				parent[find(j)] = find(i)
+			}
+		}
+	}
+
+	members := map[int][]int{}
+	var roots []int
+	for i := range titles {
+		root := find(i)
+		if _, ok := members[root]; !ok {
+			roots = append(roots, root)
+		}
+		members[root] = append(members[root], i)
+	}
+
+	var groups [][]int
+	for _, root := range roots {
+		if len(members[root]) > 1 {
+			groups = append(groups, members[root])
+		}
+	}
+	return groups
+}
+
+type FindSimilarTitlesArgs struct {
+	Collection int     `json:"collection" jsonschema:"description=Collection ID to check (0 for all bookmarks)"`
+	Threshold  float64 `json:"threshold,omitempty" jsonschema:"description=Similarity between 0 and 1 at which titles are grouped (default 0.8)"`
+	Metric     string  `json:"metric,omitempty" jsonschema:"enum=jaccard,enum=levenshtein,description=How titles are compared: shared words (jaccard, default) or edit distance (levenshtein)"`
+}
+
+func findSimilarTitlesHandler(client *RaindropClient) func(ctx context.Context, args FindSimilarTitlesArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args FindSimilarTitlesArgs) (*mcp.ToolResponse, error) {
+		threshold := args.Threshold
+		if threshold == 0 {
+			threshold = defaultSimilarityThreshold
+		}
+		if threshold < 0 || threshold > 1 {
+			return nil, fmt.Errorf("threshold must be between 0 and 1")
+		}
+		metric := args.Metric
+		if metric == "" {
+			metric = "jaccard"
+		}
+		similarity, ok := titleSimilarities[metric]
+		if !ok {
+			return nil, fmt.Errorf("invalid metric %q: must be jaccard or levenshtein", args.Metric)
+		}
+
+		ctx, cancel := client.bulkContext(ctx)
+		defer cancel()
+
+		items, err := client.listRaindrops(ctx, args.Collection, url.Values{}, maxExportItems)
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+
+		titles := make([]string, len(items))
+		for i, item := range items {
+			titles[i] = normalizeTitle(stringField(item, "title"))
+		}
+		groups := groupSimilarTitles(titles, similarity, threshold)
+
+		if len(groups) == 0 {
+			return mcp.NewToolResponse(
+				mcp.NewTextContent(fmt.Sprintf("No similar titles found among %d bookmarks.", len(items))),
+			), nil
+		}
+
+		var formattedResults strings.Builder
+		for n, group := range groups {
+			formattedResults.WriteString(fmt.Sprintf("\n\nGroup %d:", n+1))
+			for _, i := range group {
+				formattedResults.WriteString(fmt.Sprintf("\n%d: %s (%s)", intField(items[i], "_id"), stringField(items[i], "title"), stringField(items[i], "link")))
+			}
+		}
+
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(fmt.Sprintf("Found %d groups of similar titles among %d bookmarks (%s >= %.2f):%s",
+				len(groups), len(items), metric, threshold, formattedResults.String())),
+		), nil
+	}
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestTitleSimilarity(t *testing.T) {
+	if got := normalizeTitle("  Go 1.22 — Release Notes! "); got != "go 1 22 release notes" {
+		t.Errorf("Unexpected normalized title: %q", got)
+	}
+
+	if got := jaccardSimilarity("go release notes", "release notes go"); got != 1 {
+		t.Errorf("Expected identical word sets to score 1, got %v", got)
+	}
+	if got := jaccardSimilarity("go release notes", "rust release notes"); math.Abs(got-0.5) > 1e-9 {
+		t.Errorf("Expected 0.5, got %v", got)
+	}
+
+	if got := levenshteinSimilarity("kitten", "sitting"); math.Abs(got-(1-3.0/7)) > 1e-9 {
+		t.Errorf("Expected 4/7, got %v", got)
+	}
+	if got := levenshteinSimilarity("", ""); got != 1 {
+		t.Errorf("Expected empty strings to score 1, got %v", got)
+	}
+}
+
+func TestGroupSimilarTitles(t *testing.T) {
+	titles := []string{
+		"go release notes",
+		"cooking pasta at home",
+		"release notes go",
+		"",
+		"",
+		"cooking pasta at home quickly",
+		"unrelated",
+	}
+
+	groups := groupSimilarTitles(titles, jaccardSimilarity, 0.8)
+	expected := [][]int{{0, 2}, {1, 5}}
+	if !reflect.DeepEqual(groups, expected) {
+		t.Errorf("Expected %v, got %v", expected, groups)
+	}
+}
+
+func TestLevenshteinDistance(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"", "", 0},
+		{"kitten", "sitting", 3},
+		{"javascript", "javascrpt", 1},
+		{"héllo", "hello", 1},
+	}
+	for _, test := range tests {
+		if got := levenshteinDistance(test.a, test.b); got != test.expected {
+			t.Errorf("levenshteinDistance(%q, %q) = %d, expected %d", test.a, test.b, got, test.expected)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	mcp "github.com/metoro-io/mcp-golang"
+)
+
+// formatBytes renders a byte count with a binary unit, e.g. "1.5 GB".
+func formatBytes(n int) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	value, exp := float64(n)/unit, 0
+	for value >= unit && exp < 3 {
+		value /= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", value, "KMGT"[exp])
+}
+
+// storageUsage is the file upload quota of an account.
+type storageUsage struct {
+	Used  int
+	Limit int
+}
+
+// storageOf reads the quota from a /user profile, whose files object holds
+// the bytes used and the plan's limit. A missing object reads as zero.
+func storageOf(user map[string]interface{}) storageUsage {
+	files, _ := user["files"].(map[string]interface{})
+	return storageUsage{Used: intField(files, "used"), Limit: intField(files, "size")}
+}
+
+type StorageUsageArgs struct{}
+
+func storageUsageHandler(client *RaindropClient) func(ctx context.Context, args StorageUsageArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args StorageUsageArgs) (*mcp.ToolResponse, error) {
+		user, err := client.currentUser(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+
+		usage := storageOf(user)
+		responseText := fmt.Sprintf("File storage used: %s", formatBytes(usage.Used))
+		if usage.Limit > 0 {
+			responseText += fmt.Sprintf(" of %s (%.1f%%)", formatBytes(usage.Limit), float64(usage.Used)*100/float64(usage.Limit))
+		} else {
+			responseText += "\nNo upload quota reported for this account."
+		}
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(responseText),
+		), nil
+	}
+}
@@ -0,0 +1,30 @@
+package main
+
+import "testing"
+
+func TestFormatBytes(t *testing.T) {
+	tests := map[int]string{
+		0:          "0 B",
+		1023:       "1023 B",
+		1536:       "1.5 KB",
+		5 << 20:    "5.0 MB",
+		10 << 30:   "10.0 GB",
+		3 << 40:    "3.0 TB",
+		2048 << 40: "2048.0 TB",
+	}
+	for input, expected := range tests {
+		if got := formatBytes(input); got != expected {
+			t.Errorf("formatBytes(%d) = %q, expected %q", input, got, expected)
+		}
+	}
+}
+
+func TestStorageOf(t *testing.T) {
+	user := map[string]interface{}{"files": map[string]interface{}{"used": float64(1 << 20), "size": float64(10 << 30)}}
+	if got := storageOf(user); got.Used != 1<<20 || got.Limit != 10<<30 {
+		t.Errorf("Unexpected usage: %+v", got)
+	}
+	if got := storageOf(map[string]interface{}{}); got.Used != 0 || got.Limit != 0 {
+		t.Errorf("Expected zero usage without uploads, got %+v", got)
+	}
+}
@@ -0,0 +1,816 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	mcp "github.com/metoro-io/mcp-golang"
+)
+
+// tagCount is a tag together with the number of bookmarks using it.
+type tagCount struct {
+	Tag   string `json:"tag"`
+	Count int    `json:"count"`
+}
+
+// fetchTags returns the tags used in a collection (0 for all bookmarks).
+func (r *RaindropClient) fetchTags(ctx context.Context, collection int) ([]tagCount, error) {
+	result, err := r.MakeRequest(ctx, fmt.Sprintf("/tags/%d", collection), "GET", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	items, ok := result["items"].([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unable to parse tags")
+	}
+
+	var tags []tagCount
+	for _, item := range items {
+		if tag, ok := item.(map[string]interface{}); ok {
+			tags = append(tags, tagCount{Tag: stringField(tag, "_id"), Count: intField(tag, "count")})
+		}
+	}
+	return tags, nil
+}
+
+// sortTagCounts orders tags by descending count, then by name.
+func sortTagCounts(tags []tagCount) {
+	sort.Slice(tags, func(i, j int) bool {
+		if tags[i].Count != tags[j].Count {
+			return tags[i].Count > tags[j].Count
+		}
+		return tags[i].Tag < tags[j].Tag
+	})
+}
+
+// mergeTagCounts sums the counts of identical tags across several lists.
+func mergeTagCounts(lists ...[]tagCount) []tagCount {
+	totals := map[string]int{}
+	for _, list := range lists {
+		for _, tag := range list {
+			totals[tag.Tag] += tag.Count
+		}
+	}
+
+	merged := make([]tagCount, 0, len(totals))
+	for tag, count := range totals {
+		merged = append(merged, tagCount{Tag: tag, Count: count})
+	}
+	sortTagCounts(merged)
+	return merged
+}
+
+// mergeTags returns the union of the given tag lists, dropping empty tags and
+// case-insensitive duplicates while keeping the first spelling seen.
+func mergeTags(lists ...[]string) []string {
+	seen := map[string]bool{}
+	merged := []string{}
+	for _, list := range lists {
+		for _, tag := range list {
+			tag = strings.TrimSpace(tag)
+			key := strings.ToLower(tag)
+			if tag == "" || seen[key] {
+				continue
+			}
+			seen[key] = true
+			merged = append(merged, tag)
+		}
+	}
+	return merged
+}
+
+// hasTag reports whether a raindrop carries tag, ignoring case.
+func hasTag(bookmark map[string]interface{}, tag string) bool {
+	for _, t := range extractTags(bookmark) {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+type SubtreeTagsArgs struct {
+	CollectionID int `json:"collectionId" jsonschema:"required,description=ID of the collection at the top of the subtree"`
+}
+
+func subtreeTagsHandler(client *RaindropClient) func(ctx context.Context, args SubtreeTagsArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args SubtreeTagsArgs) (*mcp.ToolResponse, error) {
+		if args.CollectionID <= 0 {
+			return nil, fmt.Errorf("collection ID must be a user collection")
+		}
+
+		ctx, cancel := client.bulkContext(ctx)
+		defer cancel()
+
+		collections, err := client.fetchCollections(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+		if _, ok := collections[args.CollectionID]; !ok {
+			return nil, fmt.Errorf("collection %d not found", args.CollectionID)
+		}
+
+		ids := append([]int{args.CollectionID}, descendantIDs(collections, args.CollectionID)...)
+		var lists [][]tagCount
+		for _, id := range ids {
+			tags, err := client.fetchTags(ctx, id)
+			if err != nil {
+				return nil, fmt.Errorf("internal error: %v", err)
+			}
+			lists = append(lists, tags)
+		}
+
+		merged := mergeTagCounts(lists...)
+		if len(merged) == 0 {
+			return mcp.NewToolResponse(
+				mcp.NewTextContent(fmt.Sprintf("No tags found in %d collections.", len(ids))),
+			), nil
+		}
+
+		var formattedResults strings.Builder
+		for _, tag := range merged {
+			formattedResults.WriteString(fmt.Sprintf("\n%s: %d", tag.Tag, tag.Count))
+		}
+
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(fmt.Sprintf("Found %d tags across %d collections:%s", len(merged), len(ids), formattedResults.String())),
+		), nil
+	}
+}
+
+type AutoTagArgs struct {
+	BulkOptions
+	Collection int               `json:"collection" jsonschema:"description=Collection ID to tag (0 for all bookmarks)"`
+	Rules      map[string]string `json:"rules" jsonschema:"required,description=Map of keyword to the tag added when a bookmark's title, excerpt or domain contains the keyword"`
+	DryRun     bool              `json:"dryRun,omitempty" jsonschema:"description=Report what would be tagged without changing anything"`
+}
+
+// autoTagRules returns the rule keywords in a stable order, dropping blank ones.
+func autoTagRules(rules map[string]string) []string {
+	var keywords []string
+	for keyword, tag := range rules {
+		if strings.TrimSpace(keyword) != "" && strings.TrimSpace(tag) != "" {
+			keywords = append(keywords, keyword)
+		}
+	}
+	sort.Strings(keywords)
+	return keywords
+}
+
+// autoTagMatches returns the keywords whose tag would be new to bookmark. Keywords
+// match case-insensitively against the title, excerpt and domain.
+func autoTagMatches(bookmark map[string]interface{}, rules map[string]string, keywords []string) []string {
+	haystack := strings.ToLower(strings.Join([]string{
+		stringField(bookmark, "title"),
+		stringField(bookmark, "excerpt"),
+		stringField(bookmark, "domain"),
+	}, "\n"))
+
+	existing := map[string]bool{}
+	for _, tag := range extractTags(bookmark) {
+		existing[strings.ToLower(tag)] = true
+	}
+
+	var matched []string
+	for _, keyword := range keywords {
+		tag := strings.ToLower(strings.TrimSpace(rules[keyword]))
+		if existing[tag] || !strings.Contains(haystack, strings.ToLower(strings.TrimSpace(keyword))) {
+			continue
+		}
+		existing[tag] = true
+		matched = append(matched, keyword)
+	}
+	return matched
+}
+
+func autoTagHandler(client *RaindropClient) func(ctx context.Context, args AutoTagArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args AutoTagArgs) (*mcp.ToolResponse, error) {
+		keywords := autoTagRules(args.Rules)
+		if len(keywords) == 0 {
+			return nil, fmt.Errorf("at least one keyword rule is required")
+		}
+
+		ctx, cancel := client.bulkContext(ctx)
+		defer cancel()
+
+		items, err := client.listRaindrops(ctx, args.Collection, url.Values{}, maxExportItems)
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+
+		var ids []int
+		targets := map[int]map[string]interface{}{}
+		matches := map[int][]string{}
+		for _, item := range items {
+			if matched := autoTagMatches(item, args.Rules, keywords); len(matched) > 0 {
+				id := intField(item, "_id")
+				ids = append(ids, id)
+				targets[id] = item
+				matches[id] = matched
+			}
+		}
+
+		result := newBulkResult(ids, make([]error, len(ids)))
+		if !args.DryRun {
+			result = client.bulkApply(ctx, ids, args.StopOnError, func(ctx context.Context, id int) error {
+				tags := extractTags(targets[id])
+				for _, keyword := range matches[id] {
+					tags = append(tags, args.Rules[keyword])
+				}
+				_, err := client.updateRaindrop(ctx, id, map[string]interface{}{"tags": mergeTags(tags)})
+				return err
+			})
+		}
+
+		perRule := map[string]int{}
+		for _, id := range result.Succeeded {
+			for _, keyword := range matches[id] {
+				perRule[keyword]++
+			}
+		}
+
+		verb := "Tagged"
+		if args.DryRun {
+			verb = "Would tag"
+		}
+
+		var report strings.Builder
+		report.WriteString(fmt.Sprintf("%s %d of %d bookmarks:", verb, len(result.Succeeded), len(items)))
+		for _, keyword := range keywords {
+			report.WriteString(fmt.Sprintf("\n%q -> %s: %d", keyword, strings.TrimSpace(args.Rules[keyword]), perRule[keyword]))
+		}
+		report.WriteString(result.FailedText())
+
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(report.String()),
+		), nil
+	}
+}
+
+// secondLevelSuffixes are public suffixes with two labels, so that
+// bbc.co.uk is tagged bbc rather than co.
+var secondLevelSuffixes = map[string]bool{
+	"co.uk": true, "org.uk": true, "ac.uk": true, "gov.uk": true,
+	"com.au": true, "net.au": true, "org.au": true,
+	"co.jp": true, "ne.jp": true, "or.jp": true,
+	"co.kr": true, "or.kr": true,
+	"com.br": true, "com.cn": true, "com.mx": true, "com.tr": true,
+	"co.nz": true, "co.in": true, "co.za": true,
+}
+
+// domainTag derives a tag from a host name by dropping www, the port and the
+// public suffix, keeping the registrable name: docs.github.com becomes github.
+func domainTag(host string) string {
+	host = strings.TrimSuffix(normalizeDomain(host), ".")
+	if host == "" || net.ParseIP(host) != nil {
+		return host
+	}
+
+	labels := strings.Split(host, ".")
+	switch {
+	case len(labels) >= 3 && secondLevelSuffixes[strings.Join(labels[len(labels)-2:], ".")]:
+		return labels[len(labels)-3]
+	case len(labels) >= 2:
+		return labels[len(labels)-2]
+	default:
+		return host
+	}
+}
+
+// bookmarkHost returns the host a raindrop was saved from.
+func bookmarkHost(bookmark map[string]interface{}) string {
+	if domain := stringField(bookmark, "domain"); domain != "" {
+		return domain
+	}
+	if parsed, err := url.Parse(stringField(bookmark, "link")); err == nil {
+		return parsed.Host
+	}
+	return ""
+}
+
+type TagByDomainArgs struct {
+	BulkOptions
+	Collection int  `json:"collection" jsonschema:"description=Collection ID to tag (0 for all bookmarks)"`
+	DryRun     bool `json:"dryRun,omitempty" jsonschema:"description=Report the planned tags without changing anything"`
+}
+
+func tagByDomainHandler(client *RaindropClient) func(ctx context.Context, args TagByDomainArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args TagByDomainArgs) (*mcp.ToolResponse, error) {
+		ctx, cancel := client.bulkContext(ctx)
+		defer cancel()
+
+		items, err := client.listRaindrops(ctx, args.Collection, url.Values{}, maxExportItems)
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+
+		var ids []int
+		targets := map[int]map[string]interface{}{}
+		planned := map[int]string{}
+		for _, item := range items {
+			tag := domainTag(bookmarkHost(item))
+			if tag == "" {
+				continue
+			}
+			if hasTag(item, tag) {
+				continue
+			}
+			id := intField(item, "_id")
+			ids = append(ids, id)
+			targets[id] = item
+			planned[id] = tag
+		}
+
+		result := newBulkResult(ids, make([]error, len(ids)))
+		if !args.DryRun {
+			result = client.bulkApply(ctx, ids, args.StopOnError, func(ctx context.Context, id int) error {
+				tags := mergeTags(extractTags(targets[id]), []string{planned[id]})
+				_, err := client.updateRaindrop(ctx, id, map[string]interface{}{"tags": tags})
+				return err
+			})
+		}
+
+		totals := map[string]int{}
+		for _, id := range result.Succeeded {
+			totals[planned[id]]++
+		}
+
+		summary := make([]tagCount, 0, len(totals))
+		tagged := 0
+		for tag, count := range totals {
+			summary = append(summary, tagCount{Tag: tag, Count: count})
+			tagged += count
+		}
+		sortTagCounts(summary)
+
+		verb := "Tagged"
+		if args.DryRun {
+			verb = "Would tag"
+		}
+
+		var report strings.Builder
+		report.WriteString(fmt.Sprintf("%s %d of %d bookmarks by domain:", verb, tagged, len(items)))
+		for _, tag := range summary {
+			report.WriteString(fmt.Sprintf("\n%s: %d", tag.Tag, tag.Count))
+		}
+		report.WriteString(result.FailedText())
+
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(report.String()),
+		), nil
+	}
+}
+
+// removeTag returns tags without tag, ignoring case, and whether it was present.
+func removeTag(tags []string, tag string) ([]string, bool) {
+	kept := []string{}
+	removed := false
+	for _, t := range tags {
+		if strings.EqualFold(t, tag) {
+			removed = true
+			continue
+		}
+		kept = append(kept, t)
+	}
+	return kept, removed
+}
+
+type BulkRemoveTagArgs struct {
+	BulkOptions
+	IDs  []int  `json:"ids" jsonschema:"required,description=IDs of the bookmarks to update"`
+	Tag  string `json:"tag" jsonschema:"required,description=Tag to remove"`
+	JSON bool   `json:"json,omitempty" jsonschema:"description=Return the result as JSON"`
+}
+
+func bulkRemoveTagHandler(client *RaindropClient) func(ctx context.Context, args BulkRemoveTagArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args BulkRemoveTagArgs) (*mcp.ToolResponse, error) {
+		tag := strings.TrimSpace(args.Tag)
+		if tag == "" {
+			return nil, fmt.Errorf("tag is required")
+		}
+		if len(args.IDs) == 0 {
+			return nil, fmt.Errorf("at least one bookmark ID is required")
+		}
+
+		ctx, cancel := client.bulkContext(ctx)
+		defer cancel()
+
+		var mu sync.Mutex
+		untagged := []int{}
+		result := client.bulkApply(ctx, args.IDs, args.StopOnError, func(ctx context.Context, id int) error {
+			bookmark, err := client.getRaindrop(ctx, id)
+			if err != nil {
+				return err
+			}
+			tags, ok := removeTag(extractTags(bookmark), tag)
+			if !ok {
+				mu.Lock()
+				untagged = append(untagged, id)
+				mu.Unlock()
+				return nil
+			}
+			_, err = client.updateRaindrop(ctx, id, map[string]interface{}{"tags": tags})
+			return err
+		})
+		sort.Ints(untagged)
+
+		// Bookmarks without the tag were left alone, so they are reported
+		// apart from the ones the tag was removed from.
+		notTagged := map[int]bool{}
+		for _, id := range untagged {
+			notTagged[id] = true
+		}
+		removed := []int{}
+		for _, id := range result.Succeeded {
+			if !notTagged[id] {
+				removed = append(removed, id)
+			}
+		}
+		result.Succeeded = removed
+
+		if args.JSON {
+			return jsonResponse(map[string]interface{}{
+				"tag":       tag,
+				"result":    result,
+				"notTagged": untagged,
+			})
+		}
+
+		responseText := fmt.Sprintf("Removed %q from %d of %d bookmarks (%d did not have it):%s",
+			tag, len(result.Succeeded), len(args.IDs), len(untagged), result.Text())
+		if len(untagged) > 0 {
+			responseText += fmt.Sprintf("\nDid not have the tag (%d): %s", len(untagged), joinInts(untagged))
+		}
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(responseText),
+		), nil
+	}
+}
+
+// tagPreview explains which tags a bookmark would be saved with and why.
+type tagPreview struct {
+	Given    []string
+	Defaults []string
+	Rules    []string // "keyword -> tag" for each matching auto-tag rule
+	Domain   string
+	Tags     []string
+}
+
+// previewTags applies the default tags, auto-tag rules and (when byDomain is
+// set) the domain rule to parsed URL metadata, in the order create-bookmark,
+// auto-tag and tag-by-domain would.
+func previewTags(parsed map[string]interface{}, link string, given, defaults []string, rules map[string]string, byDomain bool) tagPreview {
+	preview := tagPreview{Given: mergeTags(given), Defaults: mergeTags(defaults)}
+	tags := mergeTags(given, defaults)
+
+	bookmark := map[string]interface{}{
+		"title":   stringField(parsed, "title"),
+		"excerpt": stringField(parsed, "excerpt"),
+		"domain":  stringField(parsed, "domain"),
+		"link":    link,
+	}
+	if stringField(bookmark, "domain") == "" {
+		if u, err := url.Parse(link); err == nil {
+			bookmark["domain"] = u.Hostname()
+		}
+	}
+	existing := make([]interface{}, len(tags))
+	for i, tag := range tags {
+		existing[i] = tag
+	}
+	bookmark["tags"] = existing
+
+	for _, keyword := range autoTagMatches(bookmark, rules, autoTagRules(rules)) {
+		tag := strings.TrimSpace(rules[keyword])
+		preview.Rules = append(preview.Rules, fmt.Sprintf("%q -> %s", keyword, tag))
+		tags = append(tags, tag)
+	}
+
+	if byDomain {
+		preview.Domain = domainTag(bookmarkHost(bookmark))
+		tags = append(tags, preview.Domain)
+	}
+
+	preview.Tags = mergeTags(tags)
+	return preview
+}
+
+type PreviewTagsArgs struct {
+	URL    string            `json:"url" jsonschema:"required,description=URL whose tags to preview"`
+	Tags   []string          `json:"tags,omitempty" jsonschema:"description=Tags you would pass to create-bookmark"`
+	Rules  map[string]string `json:"rules,omitempty" jsonschema:"description=auto-tag rules to test: map of keyword to tag"`
+	Domain bool              `json:"domain,omitempty" jsonschema:"description=Also apply the tag-by-domain rule"`
+}
+
+func previewTagsHandler(client *RaindropClient) func(ctx context.Context, args PreviewTagsArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args PreviewTagsArgs) (*mcp.ToolResponse, error) {
+		if err := validateURL(args.URL); err != nil {
+			return nil, err
+		}
+
+		parsed, err := client.parseURL(ctx, args.URL)
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+
+		preview := previewTags(parsed, args.URL, args.Tags, client.DefaultTags, args.Rules, args.Domain)
+
+		list := func(tags []string) string {
+			if len(tags) == 0 {
+				return "none"
+			}
+			return strings.Join(tags, ", ")
+		}
+
+		var report strings.Builder
+		report.WriteString(fmt.Sprintf("Tags for %s (not saved):", args.URL))
+		report.WriteString(fmt.Sprintf("\nGiven: %s", list(preview.Given)))
+		report.WriteString(fmt.Sprintf("\nDefault tags: %s", list(preview.Defaults)))
+		if len(args.Rules) > 0 {
+			report.WriteString(fmt.Sprintf("\nauto-tag rules matched: %s", list(preview.Rules)))
+		}
+		if args.Domain {
+			report.WriteString(fmt.Sprintf("\nDomain: %s", list(mergeTags([]string{preview.Domain}))))
+		}
+		report.WriteString(fmt.Sprintf("\nResult: %s", list(preview.Tags)))
+
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(report.String()),
+		), nil
+	}
+}
+
+const (
+	// defaultTagTypoDistance and maxTagTypoDistance bound the edit distance at
+	// which find-tag-typos groups tags.
+	defaultTagTypoDistance = 1
+	maxTagTypoDistance     = 3
+	// defaultTagTypoMinLength is the shortest tag find-tag-typos compares;
+	// short tags are within a small edit distance of too many others.
+	defaultTagTypoMinLength = 4
+)
+
+// tagTypoGroup is a set of tags that are likely spellings of the same tag.
+type tagTypoGroup struct {
+	Tags  []tagCount `json:"tags"`
+	Total int        `json:"total"`
+}
+
+// tagTypoGroups groups tags of at least minLength runes whose case-insensitive
+// edit distance is at most maxDistance, directly or through other tags in the
+// group. Each group lists its most used tag first; groups are ranked by their
+// combined usage.
+func tagTypoGroups(tags []tagCount, maxDistance, minLength int) []tagTypoGroup {
+	names := make([]string, len(tags))
+	for i, tag := range tags {
+		if len([]rune(tag.Tag)) >= minLength {
+			names[i] = strings.ToLower(tag.Tag)
+		}
+	}
+
+	within := func(a, b string) float64 {
+		if levenshteinDistance(a, b) <= maxDistance {
+			return 1
+		}
+		return 0
+	}
+
+	var groups []tagTypoGroup
+	for _, members := range groupSimilarTitles(names, within, 1) {
+		var group tagTypoGroup
+		for _, i := range members {
+			group.Tags = append(group.Tags, tags[i])
+			group.Total += tags[i].Count
+		}
+		sortTagCounts(group.Tags)
+		groups = append(groups, group)
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i].Total > groups[j].Total
+	})
+	return groups
+}
+
+type FindTagTyposArgs struct {
+	MaxDistance int  `json:"maxDistance,omitempty" jsonschema:"description=Largest number of character edits between tags in a group (default 1, max 3)"`
+	MinLength   int  `json:"minLength,omitempty" jsonschema:"description=Ignore tags shorter than this (default 4)"`
+	JSON        bool `json:"json,omitempty" jsonschema:"description=Return the groups as JSON"`
+}
+
+func findTagTyposHandler(client *RaindropClient) func(ctx context.Context, args FindTagTyposArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args FindTagTyposArgs) (*mcp.ToolResponse, error) {
+		maxDistance := args.MaxDistance
+		if maxDistance <= 0 {
+			maxDistance = defaultTagTypoDistance
+		}
+		if maxDistance > maxTagTypoDistance {
+			return nil, fmt.Errorf("maxDistance must be at most %d", maxTagTypoDistance)
+		}
+		minLength := args.MinLength
+		if minLength <= 0 {
+			minLength = defaultTagTypoMinLength
+		}
+
+		tags, err := client.fetchTags(ctx, 0)
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+
+		groups := tagTypoGroups(tags, maxDistance, minLength)
+		if args.JSON {
+			if groups == nil {
+				groups = []tagTypoGroup{}
+			}
+			return jsonResponse(groups)
+		}
+
+		if len(groups) == 0 {
+			return mcp.NewToolResponse(
+				mcp.NewTextContent(fmt.Sprintf("No likely tag typos among %d tags.", len(tags))),
+			), nil
+		}
+
+		var report strings.Builder
+		report.WriteString(fmt.Sprintf("Found %d groups of similar tags (most used first; consider merging into it):", len(groups)))
+		for _, group := range groups {
+			names := make([]string, len(group.Tags))
+			for i, tag := range group.Tags {
+				names[i] = fmt.Sprintf("%s (%d)", tag.Tag, tag.Count)
+			}
+			report.WriteString(fmt.Sprintf("\n%s — %d bookmarks", strings.Join(names, ", "), group.Total))
+		}
+
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(report.String()),
+		), nil
+	}
+}
+
+// defaultCooccurring and maxCooccurring bound how many tags tag-cooccurrence lists.
+const (
+	defaultCooccurring = 10
+	maxCooccurring     = 100
+)
+
+// coTagCounts counts the other tags on the bookmarks that carry tag, most
+// frequent first. Bookmarks without tag are ignored.
+func coTagCounts(items []map[string]interface{}, tag string) (tagged int, counts []tagCount) {
+	totals := map[string]int{}
+	for _, item := range items {
+		if !hasTag(item, tag) {
+			continue
+		}
+		tagged++
+		for _, other := range mergeTags(extractTags(item)) {
+			if !strings.EqualFold(other, tag) {
+				totals[other]++
+			}
+		}
+	}
+
+	counts = make([]tagCount, 0, len(totals))
+	for other, count := range totals {
+		counts = append(counts, tagCount{Tag: other, Count: count})
+	}
+	sortTagCounts(counts)
+	return tagged, counts
+}
+
+type TagCooccurrenceArgs struct {
+	Tag        string `json:"tag" jsonschema:"required,description=Tag to find related tags for"`
+	Collection int    `json:"collection" jsonschema:"description=Collection ID to look in (0 for all bookmarks)"`
+	Limit      int    `json:"limit,omitempty" jsonschema:"description=How many related tags to list (default 10, max 100)"`
+}
+
+func tagCooccurrenceHandler(client *RaindropClient) func(ctx context.Context, args TagCooccurrenceArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args TagCooccurrenceArgs) (*mcp.ToolResponse, error) {
+		tag := strings.TrimSpace(args.Tag)
+		if tag == "" {
+			return nil, fmt.Errorf("tag is required")
+		}
+		limit := args.Limit
+		if limit <= 0 {
+			limit = defaultCooccurring
+		}
+		limit = min(limit, maxCooccurring)
+
+		ctx, cancel := client.bulkContext(ctx)
+		defer cancel()
+
+		params := url.Values{}
+		params.Set("search", "#"+searchTerm(tag))
+		items, err := client.listRaindrops(ctx, args.Collection, params, maxExportItems)
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+
+		tagged, counts := coTagCounts(items, tag)
+		if tagged == 0 {
+			return mcp.NewToolResponse(
+				mcp.NewTextContent(fmt.Sprintf("No bookmarks are tagged %q.", tag)),
+			), nil
+		}
+		if len(counts) == 0 {
+			return mcp.NewToolResponse(
+				mcp.NewTextContent(fmt.Sprintf("None of the %d bookmarks tagged %q have other tags.", tagged, tag)),
+			), nil
+		}
+
+		var report strings.Builder
+		report.WriteString(fmt.Sprintf("Tags most often used with %q across %d bookmarks:", tag, tagged))
+		for _, other := range counts[:min(len(counts), limit)] {
+			report.WriteString(fmt.Sprintf("\n%s: %d (%.0f%%)", other.Tag, other.Count, 100*float64(other.Count)/float64(tagged)))
+		}
+
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(report.String()),
+		), nil
+	}
+}
+
+// maxTagCloudWeight is the weight tag-cloud gives the most used tag; the least
+// used tag gets 1.
+const maxTagCloudWeight = 5
+
+// weightedTag is a tag with its tag-cloud weight.
+type weightedTag struct {
+	Tag    string `json:"tag"`
+	Count  int    `json:"count"`
+	Weight int    `json:"weight"`
+}
+
+// tagCloud weights tags from 1 to maxTagCloudWeight by where their count falls
+// between the smallest and largest count. The scale is logarithmic, since a few
+// tags usually dwarf the rest; when every count is equal all tags get the middle
+// weight. The result is sorted by tag name.
+func tagCloud(tags []tagCount) []weightedTag {
+	cloud := make([]weightedTag, 0, len(tags))
+	if len(tags) == 0 {
+		return cloud
+	}
+
+	low, high := tags[0].Count, tags[0].Count
+	for _, tag := range tags {
+		low, high = min(low, tag.Count), max(high, tag.Count)
+	}
+	low = max(low, 1)
+	span := math.Log(float64(high)) - math.Log(float64(low))
+
+	for _, tag := range tags {
+		weight := (maxTagCloudWeight + 1) / 2
+		if span > 0 {
+			position := (math.Log(float64(max(tag.Count, 1))) - math.Log(float64(low))) / span
+			weight = 1 + min(int(position*maxTagCloudWeight), maxTagCloudWeight-1)
+		}
+		cloud = append(cloud, weightedTag{Tag: tag.Tag, Count: tag.Count, Weight: weight})
+	}
+	sort.Slice(cloud, func(i, j int) bool {
+		return strings.ToLower(cloud[i].Tag) < strings.ToLower(cloud[j].Tag)
+	})
+	return cloud
+}
+
+type TagCloudArgs struct {
+	Collection int  `json:"collection" jsonschema:"description=Collection ID (0 for all bookmarks)"`
+	JSON       bool `json:"json,omitempty" jsonschema:"description=Return the result as JSON"`
+}
+
+func tagCloudHandler(client *RaindropClient) func(ctx context.Context, args TagCloudArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args TagCloudArgs) (*mcp.ToolResponse, error) {
+		tags, err := client.fetchTags(ctx, args.Collection)
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+
+		cloud := tagCloud(tags)
+		if args.JSON {
+			return jsonResponse(map[string]interface{}{
+				"collection": args.Collection,
+				"tags":       cloud,
+			})
+		}
+
+		if len(cloud) == 0 {
+			return mcp.NewToolResponse(
+				mcp.NewTextContent("No tags found."),
+			), nil
+		}
+
+		var report strings.Builder
+		report.WriteString(fmt.Sprintf("Tag cloud for collection %d (weight 1-%d):", args.Collection, maxTagCloudWeight))
+		for _, tag := range cloud {
+			report.WriteString(fmt.Sprintf("\n%s: %d bookmarks, weight %d", tag.Tag, tag.Count, tag.Weight))
+		}
+
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(report.String()),
+		), nil
+	}
+}
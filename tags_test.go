@@ -0,0 +1,186 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMergeTagCounts(t *testing.T) {
+	got := mergeTagCounts(
+		[]tagCount{{Tag: "go", Count: 2}, {Tag: "rust", Count: 1}},
+		[]tagCount{{Tag: "go", Count: 3}, {Tag: "api", Count: 1}},
+		nil,
+	)
+	expected := []tagCount{{Tag: "go", Count: 5}, {Tag: "api", Count: 1}, {Tag: "rust", Count: 1}}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+}
+
+func TestMergeTags(t *testing.T) {
+	got := mergeTags([]string{"Go", "api", ""}, []string{"go", "agent-saved", " api "})
+	expected := []string{"Go", "api", "agent-saved"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+
+	if got := mergeTags(nil, nil); got == nil || len(got) != 0 {
+		t.Errorf("Expected empty non-nil slice, got %v", got)
+	}
+}
+
+func TestAutoTagMatches(t *testing.T) {
+	rules := map[string]string{"golang": "go", "github.com": "code", "kubernetes": "k8s", " ": "blank"}
+	keywords := autoTagRules(rules)
+	if !reflect.DeepEqual(keywords, []string{"github.com", "golang", "kubernetes"}) {
+		t.Fatalf("Unexpected keywords: %v", keywords)
+	}
+
+	bookmark := map[string]interface{}{
+		"title":   "Writing GoLang services",
+		"excerpt": "Deploying to Kubernetes",
+		"domain":  "github.com",
+		"tags":    []interface{}{"K8s"},
+	}
+	got := autoTagMatches(bookmark, rules, keywords)
+	expected := []string{"github.com", "golang"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+
+	if got := autoTagMatches(map[string]interface{}{"title": "Cooking"}, rules, keywords); len(got) != 0 {
+		t.Errorf("Expected no matches, got %v", got)
+	}
+}
+
+func TestDomainTag(t *testing.T) {
+	tests := map[string]string{
+		"github.com":       "github",
+		"www.GitHub.com":   "github",
+		"docs.github.com":  "github",
+		"news.bbc.co.uk":   "bbc",
+		"example.com:8080": "example",
+		"localhost":        "localhost",
+		"127.0.0.1":        "127.0.0.1",
+		"":                 "",
+	}
+	for host, expected := range tests {
+		if got := domainTag(host); got != expected {
+			t.Errorf("domainTag(%q) = %q, expected %q", host, got, expected)
+		}
+	}
+
+	if got := bookmarkHost(map[string]interface{}{"link": "https://go.dev/doc"}); got != "go.dev" {
+		t.Errorf("Expected host from link, got %q", got)
+	}
+}
+
+func TestRemoveTag(t *testing.T) {
+	tags, removed := removeTag([]string{"Go", "api", "go"}, "GO")
+	if !removed || !reflect.DeepEqual(tags, []string{"api"}) {
+		t.Errorf("Expected [api] with removal, got %v, %v", tags, removed)
+	}
+
+	tags, removed = removeTag([]string{"api"}, "go")
+	if removed || !reflect.DeepEqual(tags, []string{"api"}) {
+		t.Errorf("Expected tags unchanged, got %v, %v", tags, removed)
+	}
+}
+
+func TestPreviewTags(t *testing.T) {
+	parsed := map[string]interface{}{"title": "Concurrency in Go", "excerpt": "Goroutines explained"}
+	rules := map[string]string{"goroutine": "golang", "rust": "rust", "concurrency": "agent"}
+
+	preview := previewTags(parsed, "https://blog.example.co.uk/post", []string{"reading"}, []string{"agent"}, rules, true)
+
+	if !reflect.DeepEqual(preview.Rules, []string{`"goroutine" -> golang`}) {
+		t.Errorf("Expected only the goroutine rule to add a tag, got %v", preview.Rules)
+	}
+	if preview.Domain != "example" {
+		t.Errorf("Expected domain tag from the link, got %q", preview.Domain)
+	}
+	expected := []string{"reading", "agent", "golang", "example"}
+	if !reflect.DeepEqual(preview.Tags, expected) {
+		t.Errorf("Expected %v, got %v", expected, preview.Tags)
+	}
+
+	preview = previewTags(parsed, "https://example.com", nil, nil, nil, false)
+	if len(preview.Tags) != 0 || preview.Domain != "" {
+		t.Errorf("Expected no tags without rules, got %+v", preview)
+	}
+}
+
+func TestTagTypoGroups(t *testing.T) {
+	tags := []tagCount{
+		{Tag: "javascript", Count: 40},
+		{Tag: "javascrpt", Count: 2},
+		{Tag: "recipe", Count: 5},
+		{Tag: "recipes", Count: 9},
+		{Tag: "Recipies", Count: 1},
+		{Tag: "go", Count: 30},
+		{Tag: "gp", Count: 1},
+		{Tag: "python", Count: 12},
+	}
+
+	groups := tagTypoGroups(tags, 1, 4)
+	if len(groups) != 2 {
+		t.Fatalf("Expected 2 groups, got %+v", groups)
+	}
+	if groups[0].Total != 42 || groups[0].Tags[0].Tag != "javascript" || groups[0].Tags[1].Tag != "javascrpt" {
+		t.Errorf("Expected the javascript group first, got %+v", groups[0])
+	}
+	expected := []tagCount{{Tag: "recipes", Count: 9}, {Tag: "recipe", Count: 5}, {Tag: "Recipies", Count: 1}}
+	if !reflect.DeepEqual(groups[1].Tags, expected) || groups[1].Total != 15 {
+		t.Errorf("Expected %v chained through recipes, got %+v", expected, groups[1])
+	}
+
+	if groups := tagTypoGroups(tags, 1, 2); len(groups) != 3 {
+		t.Errorf("Expected go/gp to be grouped once short tags are allowed, got %+v", groups)
+	}
+}
+
+func TestCoTagCounts(t *testing.T) {
+	items := []map[string]interface{}{
+		{"tags": []interface{}{"Go", "web", "api"}},
+		{"tags": []interface{}{"go", "api"}},
+		{"tags": []interface{}{"go"}},
+		{"tags": []interface{}{"python", "api"}},
+	}
+
+	tagged, counts := coTagCounts(items, "go")
+	if tagged != 3 {
+		t.Errorf("Expected 3 bookmarks tagged go, got %d", tagged)
+	}
+	expected := []tagCount{{Tag: "api", Count: 2}, {Tag: "web", Count: 1}}
+	if !reflect.DeepEqual(counts, expected) {
+		t.Errorf("Expected %v, got %v", expected, counts)
+	}
+}
+
+func TestTagCloud(t *testing.T) {
+	cloud := tagCloud([]tagCount{
+		{Tag: "go", Count: 100},
+		{Tag: "Rust", Count: 10},
+		{Tag: "ai", Count: 1},
+		{Tag: "zig", Count: 3},
+	})
+	expected := []weightedTag{
+		{Tag: "ai", Count: 1, Weight: 1},
+		{Tag: "go", Count: 100, Weight: 5},
+		{Tag: "Rust", Count: 10, Weight: 3},
+		{Tag: "zig", Count: 3, Weight: 2},
+	}
+	if !reflect.DeepEqual(cloud, expected) {
+		t.Errorf("Expected %v, got %v", expected, cloud)
+	}
+
+	even := tagCloud([]tagCount{{Tag: "a", Count: 4}, {Tag: "b", Count: 4}})
+	for _, tag := range even {
+		if tag.Weight != 3 {
+			t.Errorf("Expected equal counts to get the middle weight, got %v", even)
+		}
+	}
+	if got := tagCloud(nil); got == nil || len(got) != 0 {
+		t.Errorf("Expected an empty, non-nil cloud, got %v", got)
+	}
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	mcp "github.com/metoro-io/mcp-golang"
+)
+
+// trashCollectionID is Raindrop's system collection for removed bookmarks.
+const trashCollectionID = -99
+
+type ListTrashArgs struct {
+	Page    int `json:"page,omitempty" jsonschema:"description=Page number starting at 0"`
+	PerPage int `json:"perPage,omitempty" jsonschema:"description=Items per page (default and max 50)"`
+}
+
+func listTrashHandler(client *RaindropClient) func(ctx context.Context, args ListTrashArgs) (*mcp.ToolResponse, error) {
+	return func(ctx context.Context, args ListTrashArgs) (*mcp.ToolResponse, error) {
+		if args.Page < 0 {
+			return nil, fmt.Errorf("page must not be negative")
+		}
+		perPage := args.PerPage
+		if perPage <= 0 || perPage > raindropPageSize {
+			perPage = raindropPageSize
+		}
+
+		params := url.Values{}
+		params.Set("page", strconv.Itoa(args.Page))
+		params.Set("perpage", strconv.Itoa(perPage))
+		params.Set("sort", "-lastUpdate")
+
+		endpoint := fmt.Sprintf("/raindrops/%d?%s", trashCollectionID, params.Encode())
+		results, err := client.MakeRequest(ctx, endpoint, "GET", nil)
+		if err != nil {
+			return nil, fmt.Errorf("internal error: %v", err)
+		}
+
+		items, ok := results["items"].([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("unable to parse results")
+		}
+		total := intField(results, "count")
+
+		if len(items) == 0 {
+			return mcp.NewToolResponse(
+				mcp.NewTextContent(fmt.Sprintf("No trashed bookmarks on page %d (trash holds %d items).", args.Page, total)),
+			), nil
+		}
+
+		var formattedResults strings.Builder
+		for _, item := range items {
+			bookmark, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			// Raindrop records no deletion time; lastUpdate matches it unless the
+			// bookmark was edited after it was trashed.
+			formattedResults.WriteString(fmt.Sprintf("\nID: %d\nTitle: %s\nURL: %s\nLast updated (≈ deleted): %s\n---",
+				intField(bookmark, "_id"), stringField(bookmark, "title"), stringField(bookmark, "link"), stringField(bookmark, "lastUpdate")))
+		}
+
+		pages := (total + perPage - 1) / perPage
+		responseText := fmt.Sprintf("Trash holds %d bookmarks (page %d of %d):%s", total, args.Page+1, pages, formattedResults.String())
+
+		return mcp.NewToolResponse(
+			mcp.NewTextContent(responseText),
+		), nil
+	}
+}